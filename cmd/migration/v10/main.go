@@ -0,0 +1,147 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"fmt"
+	"os"
+	"time"
+)
+
+var log logger.Logger
+
+// normalizedName folds the free-text user names of column so that "John  Smith"
+// and "john smith" end up as the same member.
+func normalizedName(column string) string {
+	return `COALESCE(lower(regexp_replace(trim(` + column + `), '\s+', ' ', 'g')), '')`
+}
+
+// patronTables are the tables whose user_name column is replaced by member_id,
+// with the action taken on their rows when a member is deleted.
+var patronTables = []struct {
+	Name     string
+	OnDelete string
+}{
+	{Name: "borrows", OnDelete: "RESTRICT"},
+	{Name: "reservations", OnDelete: "CASCADE"},
+	{Name: "fine_entries", OnDelete: "RESTRICT"},
+}
+
+func main() {
+	log = logger.NewLogger("migration v10")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Create the members table
+	createMembersTable := `
+		CREATE TABLE IF NOT EXISTS members (
+			id SERIAL PRIMARY KEY,
+			card_number VARCHAR(50) NOT NULL UNIQUE,
+			name VARCHAR(255) NOT NULL,
+			email VARCHAR(255) NOT NULL DEFAULT '',
+			phone VARCHAR(50) NOT NULL DEFAULT '',
+			status VARCHAR(20) NOT NULL DEFAULT 'active',
+			expires_at BIGINT NOT NULL,
+			legacy_key TEXT
+		);
+	`
+	_, err = tx.Exec(createMembersTable)
+	if err != nil {
+		log.Fatalf("Error creating table 'members': %v", err)
+	}
+	log.Infof("Created table 'members'.")
+
+	// Step 2: Create a member for every distinct user name. Rows without a
+	// name are attached to a single "Unknown patron" member.
+	createLegacyMembers := `
+		WITH names AS (
+			SELECT user_name FROM borrows
+			UNION ALL SELECT user_name FROM reservations
+			UNION ALL SELECT user_name FROM fine_entries
+		), grouped AS (
+			SELECT ` + normalizedName("user_name") + ` AS legacy_key,
+				MIN(regexp_replace(trim(user_name), '\s+', ' ', 'g')) AS name
+			FROM names
+			GROUP BY 1
+		)
+		INSERT INTO members (card_number, name, status, expires_at, legacy_key)
+		SELECT 'LEGACY-' || LPAD((ROW_NUMBER() OVER (ORDER BY legacy_key))::text, 6, '0'),
+			CASE WHEN legacy_key = '' THEN 'Unknown patron' ELSE name END,
+			'active', $1, legacy_key
+		FROM grouped;
+	`
+	res, err := tx.Exec(createLegacyMembers, time.Now().AddDate(1, 0, 0).Unix())
+	if err != nil {
+		log.Fatalf("Error creating legacy members: %v", err)
+	}
+	created, _ := res.RowsAffected()
+	log.Infof("Created %d legacy members.", created)
+
+	// Step 3: Link borrows, reservations and fines to their member
+	for _, t := range patronTables {
+		linkQuery := fmt.Sprintf(`
+			ALTER TABLE %[1]s ADD COLUMN IF NOT EXISTS member_id INTEGER;
+			UPDATE %[1]s t SET member_id = m.id
+			FROM members m
+			WHERE m.legacy_key = `+normalizedName("t.user_name")+`;
+			ALTER TABLE %[1]s ALTER COLUMN member_id SET NOT NULL;
+			ALTER TABLE %[1]s ADD CONSTRAINT fk_%[1]s_member
+				FOREIGN KEY (member_id) REFERENCES members(id) ON DELETE %[2]s;
+			ALTER TABLE %[1]s DROP COLUMN IF EXISTS user_name;
+		`, t.Name, t.OnDelete)
+		_, err = tx.Exec(linkQuery)
+		if err != nil {
+			log.Fatalf("Error linking table '%s' to members: %v", t.Name, err)
+		}
+		log.Infof("Replaced column 'user_name' of table '%s' with 'member_id'.", t.Name)
+	}
+
+	// Step 4: Recreate the indexes on the new column and drop the migration helper
+	createIndexes := `
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_reservations_active_member
+			ON reservations (book_id, member_id) WHERE status IN ('pending', 'ready');
+		CREATE INDEX IF NOT EXISTS idx_fine_entries_member_id ON fine_entries (member_id);
+		CREATE INDEX IF NOT EXISTS idx_borrows_member_id ON borrows (member_id);
+		ALTER TABLE members DROP COLUMN legacy_key;
+	`
+	_, err = tx.Exec(createIndexes)
+	if err != nil {
+		log.Fatalf("Error creating indexes on 'member_id': %v", err)
+	}
+	log.Infof("Created indexes on 'member_id'.")
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"os"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v11")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Add 'category' column to members and books tables
+	addColumnsQuery := `
+		ALTER TABLE members
+		ADD COLUMN IF NOT EXISTS category VARCHAR(50) NOT NULL DEFAULT 'standard';
+		ALTER TABLE books
+		ADD COLUMN IF NOT EXISTS category VARCHAR(50) NOT NULL DEFAULT '';
+	`
+	_, err = tx.Exec(addColumnsQuery)
+	if err != nil {
+		log.Fatalf("Error adding new column 'category': %v", err)
+	}
+	log.Infof("Added new column 'category' to tables 'members' and 'books'.")
+
+	// Step 2: Create the loan_policies table. An empty book category is the
+	// general policy of the member category.
+	createPoliciesTable := `
+		CREATE TABLE IF NOT EXISTS loan_policies (
+			id SERIAL PRIMARY KEY,
+			member_category VARCHAR(50) NOT NULL,
+			book_category VARCHAR(50) NOT NULL DEFAULT '',
+			max_loans INTEGER NOT NULL DEFAULT 0 CHECK (max_loans >= 0),
+			loan_period_days INTEGER NOT NULL CHECK (loan_period_days > 0),
+			max_renewals INTEGER NOT NULL DEFAULT 0 CHECK (max_renewals >= 0),
+			UNIQUE (member_category, book_category)
+		);
+	`
+	_, err = tx.Exec(createPoliciesTable)
+	if err != nil {
+		log.Fatalf("Error creating table 'loan_policies': %v", err)
+	}
+	log.Infof("Created table 'loan_policies'.")
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"os"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v12")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Create the book_authors table
+	createBookAuthorsTable := `
+		CREATE TABLE IF NOT EXISTS book_authors (
+			book_id INTEGER NOT NULL,
+			author_id INTEGER NOT NULL,
+			role VARCHAR(20) NOT NULL DEFAULT 'author',
+			position INTEGER NOT NULL DEFAULT 1,
+			PRIMARY KEY (book_id, author_id, role),
+			FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+			FOREIGN KEY (author_id) REFERENCES authors(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_book_authors_author_id ON book_authors (author_id);
+	`
+	_, err = tx.Exec(createBookAuthorsTable)
+	if err != nil {
+		log.Fatalf("Error creating table 'book_authors': %v", err)
+	}
+	log.Infof("Created table 'book_authors'.")
+
+	// Step 2: Copy the author of every book into book_authors
+	copyAuthorsQuery := `
+		INSERT INTO book_authors (book_id, author_id, role, position)
+		SELECT id, author_id, 'author', 1
+		FROM books
+		WHERE author_id IS NOT NULL
+		ON CONFLICT DO NOTHING;
+	`
+	res, err := tx.Exec(copyAuthorsQuery)
+	if err != nil {
+		log.Fatalf("Error copying book authors: %v", err)
+	}
+	copied, _ := res.RowsAffected()
+	log.Infof("Copied %d book authors.", copied)
+
+	// Step 3: Drop the 'author_id' column from books table
+	dropColumnQuery := `
+		ALTER TABLE books
+		DROP COLUMN IF EXISTS author_id;
+	`
+	_, err = tx.Exec(dropColumnQuery)
+	if err != nil {
+		log.Fatalf("Error dropping column 'author_id': %v", err)
+	}
+	log.Infof("Dropped column 'author_id'.")
+}
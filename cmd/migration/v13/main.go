@@ -0,0 +1,72 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"os"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v13")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Add 'isbn13' and 'isbn10' columns to books table
+	addColumnsQuery := `
+		ALTER TABLE books
+		ADD COLUMN IF NOT EXISTS isbn13 VARCHAR(13) NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS isbn10 VARCHAR(10) NOT NULL DEFAULT '';
+	`
+	_, err = tx.Exec(addColumnsQuery)
+	if err != nil {
+		log.Fatalf("Error adding new columns 'isbn13' and 'isbn10': %v", err)
+	}
+	log.Infof("Added new columns 'isbn13' and 'isbn10'.")
+
+	// Step 2: Make ISBNs unique among the books that have one
+	createIndexQuery := `
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_books_isbn13 ON books (isbn13) WHERE isbn13 <> '';
+	`
+	_, err = tx.Exec(createIndexQuery)
+	if err != nil {
+		log.Fatalf("Error creating unique index on 'isbn13': %v", err)
+	}
+	log.Infof("Created unique index on 'isbn13'.")
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"os"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v14")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Create the genres table, sub-genres point at their parent
+	createGenresQuery := `
+		CREATE TABLE IF NOT EXISTS genres (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			parent_id INTEGER REFERENCES genres(id) ON DELETE RESTRICT
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_genres_parent_name ON genres (COALESCE(parent_id, 0), lower(name));
+	`
+	_, err = tx.Exec(createGenresQuery)
+	if err != nil {
+		log.Fatalf("Error creating table 'genres': %v", err)
+	}
+	log.Infof("Created table 'genres'.")
+
+	// Step 2: Create the tags table
+	createTagsQuery := `
+		CREATE TABLE IF NOT EXISTS tags (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(100) NOT NULL UNIQUE
+		);
+	`
+	_, err = tx.Exec(createTagsQuery)
+	if err != nil {
+		log.Fatalf("Error creating table 'tags': %v", err)
+	}
+	log.Infof("Created table 'tags'.")
+
+	// Step 3: Create the join tables between books and genres or tags
+	createJoinTablesQuery := `
+		CREATE TABLE IF NOT EXISTS book_genres (
+			book_id INTEGER NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+			genre_id INTEGER NOT NULL REFERENCES genres(id) ON DELETE CASCADE,
+			PRIMARY KEY (book_id, genre_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_book_genres_genre_id ON book_genres (genre_id);
+
+		CREATE TABLE IF NOT EXISTS book_tags (
+			book_id INTEGER NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+			tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+			PRIMARY KEY (book_id, tag_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_book_tags_tag_id ON book_tags (tag_id);
+	`
+	_, err = tx.Exec(createJoinTablesQuery)
+	if err != nil {
+		log.Fatalf("Error creating tables 'book_genres' and 'book_tags': %v", err)
+	}
+	log.Infof("Created tables 'book_genres' and 'book_tags'.")
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"os"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v15")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Create the publishers table
+	createPublishersQuery := `
+		CREATE TABLE IF NOT EXISTS publishers (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_publishers_name ON publishers (lower(name));
+	`
+	_, err = tx.Exec(createPublishersQuery)
+	if err != nil {
+		log.Fatalf("Error creating table 'publishers': %v", err)
+	}
+	log.Infof("Created table 'publishers'.")
+
+	// Step 2: Add the edition columns to books table
+	addColumnsQuery := `
+		ALTER TABLE books
+		ADD COLUMN IF NOT EXISTS publisher_id INTEGER REFERENCES publishers(id) ON DELETE RESTRICT,
+		ADD COLUMN IF NOT EXISTS edition VARCHAR(255) NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS page_count INTEGER NOT NULL DEFAULT 0 CHECK (page_count >= 0),
+		ADD COLUMN IF NOT EXISTS language VARCHAR(3) NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS format VARCHAR(20) NOT NULL DEFAULT ''
+			CHECK (format IN ('', 'hardcover', 'paperback', 'ebook', 'audiobook'));
+	`
+	_, err = tx.Exec(addColumnsQuery)
+	if err != nil {
+		log.Fatalf("Error adding the edition columns: %v", err)
+	}
+	log.Infof("Added new columns 'publisher_id', 'edition', 'page_count', 'language' and 'format'.")
+
+	// Step 3: Index the columns books are commonly filtered on
+	createIndexesQuery := `
+		CREATE INDEX IF NOT EXISTS idx_books_publisher_id ON books (publisher_id);
+		CREATE INDEX IF NOT EXISTS idx_books_language ON books (language);
+		CREATE INDEX IF NOT EXISTS idx_books_format ON books (format);
+	`
+	_, err = tx.Exec(createIndexesQuery)
+	if err != nil {
+		log.Fatalf("Error creating indexes on the edition columns: %v", err)
+	}
+	log.Infof("Created indexes on 'publisher_id', 'language' and 'format'.")
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"os"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v16")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Create the branches table with the branch holding the existing collection
+	createBranchesQuery := `
+		CREATE TABLE IF NOT EXISTS branches (
+			id SERIAL PRIMARY KEY,
+			code VARCHAR(20) NOT NULL UNIQUE,
+			name VARCHAR(255) NOT NULL,
+			address TEXT NOT NULL DEFAULT ''
+		);
+		INSERT INTO branches (code, name) VALUES ('MAIN', 'Main library')
+		ON CONFLICT (code) DO NOTHING;
+	`
+	_, err = tx.Exec(createBranchesQuery)
+	if err != nil {
+		log.Fatalf("Error creating table 'branches': %v", err)
+	}
+	log.Infof("Created table 'branches' with the 'MAIN' branch.")
+
+	// Step 2: Assign every copy to a branch
+	addCopyBranchQuery := `
+		ALTER TABLE book_copies
+		ADD COLUMN IF NOT EXISTS branch_id INTEGER REFERENCES branches(id) ON DELETE RESTRICT;
+		UPDATE book_copies SET branch_id = (SELECT id FROM branches WHERE code = 'MAIN')
+		WHERE branch_id IS NULL;
+		ALTER TABLE book_copies ALTER COLUMN branch_id SET NOT NULL;
+		CREATE INDEX IF NOT EXISTS idx_book_copies_book_branch_status ON book_copies (book_id, branch_id, status);
+	`
+	_, err = tx.Exec(addCopyBranchQuery)
+	if err != nil {
+		log.Fatalf("Error adding column 'branch_id' to table 'book_copies': %v", err)
+	}
+	log.Infof("Added new column 'branch_id' to table 'book_copies'.")
+
+	// Step 3: Record the lending and return branches of borrows
+	addBorrowBranchesQuery := `
+		ALTER TABLE borrows
+		ADD COLUMN IF NOT EXISTS branch_id INTEGER REFERENCES branches(id) ON DELETE RESTRICT,
+		ADD COLUMN IF NOT EXISTS return_branch_id INTEGER REFERENCES branches(id) ON DELETE RESTRICT;
+		UPDATE borrows SET branch_id = COALESCE(
+			(SELECT c.branch_id FROM book_copies c WHERE c.id = borrows.copy_id),
+			(SELECT id FROM branches WHERE code = 'MAIN'))
+		WHERE branch_id IS NULL;
+		UPDATE borrows SET return_branch_id = branch_id
+		WHERE returned_at IS NOT NULL AND return_branch_id IS NULL;
+		ALTER TABLE borrows ALTER COLUMN branch_id SET NOT NULL;
+		CREATE INDEX IF NOT EXISTS idx_borrows_branch_id ON borrows (branch_id);
+	`
+	_, err = tx.Exec(addBorrowBranchesQuery)
+	if err != nil {
+		log.Fatalf("Error adding the branch columns to table 'borrows': %v", err)
+	}
+	log.Infof("Added new columns 'branch_id' and 'return_branch_id' to table 'borrows'.")
+
+	// Step 4: Create the transfers table, allowing a single open transfer per copy
+	createTransfersQuery := `
+		CREATE TABLE IF NOT EXISTS transfers (
+			id SERIAL PRIMARY KEY,
+			copy_id INTEGER NOT NULL REFERENCES book_copies(id) ON DELETE RESTRICT,
+			from_branch_id INTEGER NOT NULL REFERENCES branches(id) ON DELETE RESTRICT,
+			to_branch_id INTEGER NOT NULL REFERENCES branches(id) ON DELETE RESTRICT,
+			status VARCHAR(20) NOT NULL,
+			requested_at BIGINT NOT NULL,
+			shipped_at BIGINT,
+			received_at BIGINT,
+			note TEXT NOT NULL DEFAULT ''
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_transfers_open_copy ON transfers (copy_id)
+		WHERE status IN ('requested', 'in_transit');
+	`
+	_, err = tx.Exec(createTransfersQuery)
+	if err != nil {
+		log.Fatalf("Error creating table 'transfers': %v", err)
+	}
+	log.Infof("Created table 'transfers'.")
+
+	// Step 5: Create the transfer_events table holding the audit trail of transfers
+	createTransferEventsQuery := `
+		CREATE TABLE IF NOT EXISTS transfer_events (
+			id SERIAL PRIMARY KEY,
+			transfer_id INTEGER NOT NULL REFERENCES transfers(id) ON DELETE CASCADE,
+			status VARCHAR(20) NOT NULL,
+			occurred_at BIGINT NOT NULL,
+			note TEXT NOT NULL DEFAULT ''
+		);
+		CREATE INDEX IF NOT EXISTS idx_transfer_events_transfer_id ON transfer_events (transfer_id);
+	`
+	_, err = tx.Exec(createTransferEventsQuery)
+	if err != nil {
+		log.Fatalf("Error creating table 'transfer_events': %v", err)
+	}
+	log.Infof("Created table 'transfer_events'.")
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"os"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v17")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Create the book_covers table describing the cover image of each book
+	createBookCoversQuery := `
+		CREATE TABLE IF NOT EXISTS book_covers (
+			book_id INTEGER PRIMARY KEY REFERENCES books(id) ON DELETE CASCADE,
+			content_type VARCHAR(50) NOT NULL,
+			width INTEGER NOT NULL,
+			height INTEGER NOT NULL,
+			size BIGINT NOT NULL,
+			checksum CHAR(64) NOT NULL,
+			updated_at BIGINT NOT NULL
+		);
+	`
+	_, err = tx.Exec(createBookCoversQuery)
+	if err != nil {
+		log.Fatalf("Error creating table 'book_covers': %v", err)
+	}
+	log.Infof("Created table 'book_covers'.")
+}
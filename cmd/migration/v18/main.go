@@ -0,0 +1,93 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v18")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Check the text search configuration, interpolated in the generated columns below
+	language := cfg.Search.Language
+	var found bool
+	err = tx.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = $1)", language).Scan(&found)
+	if err != nil {
+		log.Fatalf("Error looking up text search configuration %q: %v", language, err)
+	}
+	if !found || strings.ContainsAny(language, `'"\`) {
+		log.Fatalf("Unknown text search configuration %q", language)
+	}
+	log.Infof("Using text search configuration '%s'.", language)
+
+	// Step 2: (Re)create the search vector of books, weighting titles above categories
+	booksVectorQuery := fmt.Sprintf(`
+		ALTER TABLE books DROP COLUMN IF EXISTS search_vector;
+		ALTER TABLE books ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('%[1]s', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('%[1]s', coalesce(category, '')), 'C')
+		) STORED;
+		CREATE INDEX IF NOT EXISTS idx_books_search_vector ON books USING GIN (search_vector);
+	`, language)
+	_, err = tx.Exec(booksVectorQuery)
+	if err != nil {
+		log.Fatalf("Error adding column 'search_vector' to table 'books': %v", err)
+	}
+	log.Infof("Added new column 'search_vector' to table 'books'.")
+
+	// Step 3: (Re)create the search vector of authors
+	authorsVectorQuery := fmt.Sprintf(`
+		ALTER TABLE authors DROP COLUMN IF EXISTS search_vector;
+		ALTER TABLE authors ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+			to_tsvector('%[1]s', coalesce(name, ''))
+		) STORED;
+		CREATE INDEX IF NOT EXISTS idx_authors_search_vector ON authors USING GIN (search_vector);
+	`, language)
+	_, err = tx.Exec(authorsVectorQuery)
+	if err != nil {
+		log.Fatalf("Error adding column 'search_vector' to table 'authors': %v", err)
+	}
+	log.Infof("Added new column 'search_vector' to table 'authors'.")
+}
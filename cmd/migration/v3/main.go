@@ -12,6 +12,7 @@ import (
 
 	"borrow_book/internal/config"
 	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/postgres"
 	"borrow_book/internal/initialize"
 	"borrow_book/internal/repository"
 	"borrow_book/pkg/logger"
@@ -61,28 +62,7 @@ func main() {
 	authorRepo := repository.NewAuthorRepository(db)
 	bookRepo := repository.NewBookRepository(db)
 
-	// Begin the migration within a transaction
-	tx, err := db.Beginx()
-	if err != nil {
-		log.Fatalf("Error beginning transaction: %v", err)
-	}
-
-	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
-		} else if err != nil {
-			tx.Rollback()
-			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
-		} else {
-			err = tx.Commit()
-			if err != nil {
-				log.Fatalf("Error committing transaction: %v", err)
-			}
-			log.Infof("Migration v3 completed successfully.")
-		}
-	}()
+	txManager := postgres.NewTxManager(db)
 
 	// Resolve absolute paths
 	authorAbsPath, err := filepath.Abs(*authorFilePath)
@@ -95,39 +75,47 @@ func main() {
 		log.Fatalf("Error resolving book file path: %v", err)
 	}
 
-	// Step 1: Insert authors
+	// Read both files before touching the database
 	authors, err := readAuthors(authorAbsPath)
 	if err != nil {
 		log.Fatalf("Error reading authors from file: %v", err)
 	}
 
-	if len(authors) > 0 {
-		log.Infof("Inserting %d authors.", len(authors))
-		err = insertAuthors(ctx, authors, authorRepo)
-		if err != nil {
-			log.Fatalf("Error inserting authors: %v", err)
-		}
-		log.Infof("Inserted %d authors successfully.", len(authors))
-	} else {
-		log.Infof("No authors to insert.")
-	}
-
-	// Step 2: Insert books
 	books, err := readBooks(bookAbsPath)
 	if err != nil {
 		log.Fatalf("Error reading books from file: %v", err)
 	}
 
-	if len(books) > 0 {
-		log.Infof("Inserting %d books.", len(books))
-		err = insertBooks(ctx, books, authorRepo, bookRepo)
-		if err != nil {
-			log.Fatalf("Error inserting books: %v", err)
+	// The repositories run in the transaction carried by the context, so an
+	// error in any step rolls back every author and book inserted before it
+	err = txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Step 1: Insert authors
+		if len(authors) > 0 {
+			log.Infof("Inserting %d authors.", len(authors))
+			if err := insertAuthors(ctx, authors, authorRepo); err != nil {
+				return fmt.Errorf("inserting authors: %w", err)
+			}
+			log.Infof("Inserted %d authors successfully.", len(authors))
+		} else {
+			log.Infof("No authors to insert.")
 		}
-		log.Infof("Inserted %d books successfully.", len(books))
-	} else {
-		log.Infof("No books to insert.")
+
+		// Step 2: Insert books
+		if len(books) > 0 {
+			log.Infof("Inserting %d books.", len(books))
+			if err := insertBooks(ctx, books, authorRepo, bookRepo); err != nil {
+				return fmt.Errorf("inserting books: %w", err)
+			}
+			log.Infof("Inserted %d books successfully.", len(books))
+		} else {
+			log.Infof("No books to insert.")
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
 	}
+	log.Infof("Migration v3 completed successfully.")
 }
 
 // readAuthors reads the authors from the given file path.
@@ -267,12 +255,14 @@ func insertBooks(ctx context.Context, books []BookWithAuthorName, authorRepo rep
 		if author == nil {
 			return fmt.Errorf("author '%s' not found for book '%s'", authorName, book.Title)
 		}
-		book.AuthorID = author.ID
+		book.Contributors = []model.BookContributor{
+			{AuthorID: author.ID, Role: model.ContributorRoleAuthor, Position: 1},
+		}
 
 		// Check if the book exists by Title and AuthorID
-		existingBook, err := bookRepo.GetBookByTitleAndAuthorID(ctx, book.Title, book.AuthorID)
+		existingBook, err := bookRepo.GetBookByTitleAndAuthorID(ctx, book.Title, author.ID)
 		if err != nil {
-			return fmt.Errorf("checking existence for book Title '%s' and AuthorID %d: %w", book.Title, book.AuthorID, err)
+			return fmt.Errorf("checking existence for book Title '%s' and AuthorID %d: %w", book.Title, author.ID, err)
 		}
 		if existingBook != nil {
 			log.Infof("Book already exists (ID: %d, Title: %s). Skipping insertion.", existingBook.ID, existingBook.Title)
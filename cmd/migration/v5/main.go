@@ -0,0 +1,86 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"os"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v5")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Add 'due_at' and 'returned_at' columns to borrows table
+	addColumnsQuery := `
+		ALTER TABLE borrows
+		ADD COLUMN IF NOT EXISTS due_at BIGINT,
+		ADD COLUMN IF NOT EXISTS returned_at BIGINT;
+	`
+	_, err = tx.Exec(addColumnsQuery)
+	if err != nil {
+		log.Fatalf("Error adding new columns 'due_at' and 'returned_at': %v", err)
+	}
+	log.Infof("Added new columns 'due_at' and 'returned_at'.")
+
+	// Step 2: Populate 'due_at' of existing borrows from the configured loan period
+	loanPeriodSeconds := int64(cfg.Library.LoanPeriodDays) * 24 * 60 * 60
+	updateColumnQuery := `
+		UPDATE borrows
+		SET due_at = borrowed_at + $1
+		WHERE due_at IS NULL;
+	`
+	_, err = tx.Exec(updateColumnQuery, loanPeriodSeconds)
+	if err != nil {
+		log.Fatalf("Error populating 'due_at': %v", err)
+	}
+	log.Infof("Populated 'due_at' with a loan period of %d days.", cfg.Library.LoanPeriodDays)
+
+	// Step 3: Every borrow has a due date from now on
+	setNotNullQuery := `
+		ALTER TABLE borrows
+		ALTER COLUMN due_at SET NOT NULL;
+	`
+	_, err = tx.Exec(setNotNullQuery)
+	if err != nil {
+		log.Fatalf("Error setting 'due_at' to NOT NULL: %v", err)
+	}
+	log.Infof("Set 'due_at' to NOT NULL.")
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+)
+
+var log logger.Logger
+
+// legacyBook is a book together with its borrows ordered by id.
+type legacyBook struct {
+	ID          int
+	OpenBorrows []int
+	PastBorrows []int
+}
+
+func main() {
+	log = logger.NewLogger("migration v6")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Create the book_copies table
+	createCopiesTable := `
+		CREATE TABLE IF NOT EXISTS book_copies (
+			id SERIAL PRIMARY KEY,
+			book_id INTEGER NOT NULL,
+			barcode VARCHAR(64) NOT NULL UNIQUE,
+			status VARCHAR(32) NOT NULL DEFAULT 'available',
+			acquired_at BIGINT NOT NULL,
+			FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_book_copies_book_id_status ON book_copies (book_id, status);
+	`
+	_, err = tx.Exec(createCopiesTable)
+	if err != nil {
+		log.Fatalf("Error creating table 'book_copies': %v", err)
+	}
+	log.Infof("Created table 'book_copies'.")
+
+	// Step 2: Add 'copy_id' column to borrows table
+	addColumnQuery := `
+		ALTER TABLE borrows
+		ADD COLUMN IF NOT EXISTS copy_id INTEGER REFERENCES book_copies(id);
+	`
+	_, err = tx.Exec(addColumnQuery)
+	if err != nil {
+		log.Fatalf("Error adding new column 'copy_id': %v", err)
+	}
+	log.Infof("Added new column 'copy_id'.")
+
+	// Step 3: Create copies for existing books and link their borrows
+	books, err := readLegacyBooks(tx)
+	if err != nil {
+		log.Fatalf("Error reading existing books and borrows: %v", err)
+	}
+	acquiredAt := time.Now().Unix()
+	for _, book := range books {
+		err = createLegacyCopies(tx, book, acquiredAt)
+		if err != nil {
+			log.Fatalf("Error creating copies for book %d: %v", book.ID, err)
+		}
+	}
+	log.Infof("Created copies for %d existing books.", len(books))
+
+	// Step 4: Every borrow points at a copy from now on
+	setNotNullQuery := `
+		ALTER TABLE borrows
+		ALTER COLUMN copy_id SET NOT NULL;
+	`
+	_, err = tx.Exec(setNotNullQuery)
+	if err != nil {
+		log.Fatalf("Error setting 'copy_id' to NOT NULL: %v", err)
+	}
+	log.Infof("Set 'copy_id' to NOT NULL.")
+}
+
+// readLegacyBooks loads the books that have no copies yet, together with their borrows.
+func readLegacyBooks(tx *sql.Tx) ([]legacyBook, error) {
+	rows, err := tx.Query(`
+		SELECT b.id, br.id, br.returned_at IS NULL
+		FROM books b
+		LEFT JOIN borrows br ON br.book_id = b.id
+		WHERE NOT EXISTS (SELECT 1 FROM book_copies c WHERE c.book_id = b.id)
+		ORDER BY b.id, br.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var books []legacyBook
+	for rows.Next() {
+		var (
+			bookID   int
+			borrowID sql.NullInt64
+			open     sql.NullBool
+		)
+		if err := rows.Scan(&bookID, &borrowID, &open); err != nil {
+			return nil, err
+		}
+		if len(books) == 0 || books[len(books)-1].ID != bookID {
+			books = append(books, legacyBook{ID: bookID})
+		}
+		if !borrowID.Valid {
+			continue
+		}
+		book := &books[len(books)-1]
+		if open.Bool {
+			book.OpenBorrows = append(book.OpenBorrows, int(borrowID.Int64))
+		} else {
+			book.PastBorrows = append(book.PastBorrows, int(borrowID.Int64))
+		}
+	}
+	return books, rows.Err()
+}
+
+// createLegacyCopies creates one copy per open borrow of the book, at least one,
+// lends each open borrow its own copy and attaches past borrows to the first copy.
+func createLegacyCopies(tx *sql.Tx, book legacyBook, acquiredAt int64) error {
+	count := len(book.OpenBorrows)
+	if count == 0 {
+		count = 1
+	}
+
+	copyIDs := make([]int, count)
+	for i := range copyIDs {
+		status := model.CopyStatusAvailable
+		if i < len(book.OpenBorrows) {
+			status = model.CopyStatusOnLoan
+		}
+		barcode := fmt.Sprintf("LEGACY-%06d-%02d", book.ID, i+1)
+		err := tx.QueryRow(
+			"INSERT INTO book_copies (book_id, barcode, status, acquired_at) VALUES ($1, $2, $3, $4) RETURNING id",
+			book.ID, barcode, status, acquiredAt,
+		).Scan(&copyIDs[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, borrowID := range book.OpenBorrows {
+		if _, err := tx.Exec("UPDATE borrows SET copy_id = $1 WHERE id = $2", copyIDs[i], borrowID); err != nil {
+			return err
+		}
+	}
+	for _, borrowID := range book.PastBorrows {
+		if _, err := tx.Exec("UPDATE borrows SET copy_id = $1 WHERE id = $2", copyIDs[0], borrowID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
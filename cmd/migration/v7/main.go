@@ -0,0 +1,75 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"os"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v7")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Create the reservations table
+	createReservationsTable := `
+		CREATE TABLE IF NOT EXISTS reservations (
+			id SERIAL PRIMARY KEY,
+			book_id INTEGER NOT NULL,
+			user_name VARCHAR(255) NOT NULL,
+			status VARCHAR(32) NOT NULL DEFAULT 'pending',
+			copy_id INTEGER,
+			created_at BIGINT NOT NULL,
+			ready_at BIGINT,
+			expires_at BIGINT,
+			FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+			FOREIGN KEY (copy_id) REFERENCES book_copies(id) ON DELETE SET NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_reservations_book_id_status ON reservations (book_id, status, created_at);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_reservations_active_user
+			ON reservations (book_id, user_name)
+			WHERE status IN ('pending', 'ready');
+	`
+	_, err = tx.Exec(createReservationsTable)
+	if err != nil {
+		log.Fatalf("Error creating table 'reservations': %v", err)
+	}
+	log.Infof("Created table 'reservations'.")
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"os"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v8")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Create the fine_entries table
+	createFineEntriesTable := `
+		CREATE TABLE IF NOT EXISTS fine_entries (
+			id SERIAL PRIMARY KEY,
+			user_name VARCHAR(255) NOT NULL,
+			borrow_id INTEGER,
+			kind VARCHAR(16) NOT NULL,
+			amount BIGINT NOT NULL CHECK (amount > 0),
+			reason TEXT NOT NULL DEFAULT '',
+			created_at BIGINT NOT NULL,
+			FOREIGN KEY (borrow_id) REFERENCES borrows(id) ON DELETE SET NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_fine_entries_user_name ON fine_entries (user_name);
+	`
+	_, err = tx.Exec(createFineEntriesTable)
+	if err != nil {
+		log.Fatalf("Error creating table 'fine_entries': %v", err)
+	}
+	log.Infof("Created table 'fine_entries'.")
+}
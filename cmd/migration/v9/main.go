@@ -0,0 +1,81 @@
+package main
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/initialize"
+	"borrow_book/pkg/logger"
+	"os"
+)
+
+var log logger.Logger
+
+func main() {
+	log = logger.NewLogger("migration v9")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Errorf("config loading error: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize databases
+	db, err := initialize.InitDatabases(cfg, &log)
+	if err != nil {
+		log.Errorf("database initialization error: %v", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Begin the migration within a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatalf("Error beginning transaction: %v", err)
+	}
+
+	// Defer a rollback in case anything fails. If the transaction is committed, this will do nothing.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			log.Fatalf("Panic occurred: %v. Transaction rolled back.", p)
+		} else if err != nil {
+			tx.Rollback()
+			log.Fatalf("Error occurred: %v. Transaction rolled back.", err)
+		} else {
+			err = tx.Commit()
+			if err != nil {
+				log.Fatalf("Error committing transaction: %v", err)
+			}
+			log.Infof("Migration completed successfully.")
+		}
+	}()
+
+	// Step 1: Add 'renewal_count' column to borrows table
+	addColumnQuery := `
+		ALTER TABLE borrows
+		ADD COLUMN IF NOT EXISTS renewal_count INTEGER NOT NULL DEFAULT 0;
+	`
+	_, err = tx.Exec(addColumnQuery)
+	if err != nil {
+		log.Fatalf("Error adding new column 'renewal_count': %v", err)
+	}
+	log.Infof("Added new column 'renewal_count'.")
+
+	// Step 2: Create the borrow_renewals table
+	createRenewalsTable := `
+		CREATE TABLE IF NOT EXISTS borrow_renewals (
+			id SERIAL PRIMARY KEY,
+			borrow_id INTEGER NOT NULL,
+			attempted_at BIGINT NOT NULL,
+			succeeded BOOLEAN NOT NULL,
+			previous_due_at BIGINT NOT NULL,
+			new_due_at BIGINT,
+			reason TEXT NOT NULL DEFAULT '',
+			FOREIGN KEY (borrow_id) REFERENCES borrows(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_borrow_renewals_borrow_id ON borrow_renewals (borrow_id);
+	`
+	_, err = tx.Exec(createRenewalsTable)
+	if err != nil {
+		log.Fatalf("Error creating table 'borrow_renewals': %v", err)
+	}
+	log.Infof("Created table 'borrow_renewals'.")
+}
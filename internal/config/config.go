@@ -14,6 +14,10 @@ type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
 	CORS     CORSConfig
+	Library  LibraryConfig
+	Storage  StorageConfig
+	Search   SearchConfig
+	Paging   PagingConfig
 }
 
 // ServerConfig holds server-related configurations.
@@ -38,6 +42,42 @@ type CORSConfig struct {
 	MaxAge           time.Duration `mapstructure:"max_age"`
 }
 
+// LibraryConfig holds circulation-related configurations.
+type LibraryConfig struct {
+	// Loan settings of members whose category has no loan policy
+	LoanPeriodDays int `mapstructure:"loan_period_days"`
+	HoldPickupDays int `mapstructure:"hold_pickup_days"` // Days a copy stays held for a ready reservation
+	MaxRenewals    int `mapstructure:"max_renewals"`     // Times a loan can be extended
+	MaxLoans       int `mapstructure:"max_loans"`        // Loans a patron may hold at once, 0 for no limit
+
+	// Fine amounts are expressed in cents
+	FineDailyRate      int64 `mapstructure:"fine_daily_rate"`
+	FineMaxAmount      int64 `mapstructure:"fine_max_amount"`      // Cap of the fine of a single loan, 0 for no cap
+	FineBlockThreshold int64 `mapstructure:"fine_block_threshold"` // Patrons owing more cannot borrow
+}
+
+// StorageConfig holds file storage-related configurations.
+type StorageConfig struct {
+	Driver   string // Only "local" for now
+	LocalDir string `mapstructure:"local_dir"` // Root directory of the local driver
+
+	MaxCoverSize     int64 `mapstructure:"max_cover_size"`      // Largest accepted cover upload, in bytes
+	CoverCacheMaxAge int   `mapstructure:"cover_cache_max_age"` // Seconds clients may cache a cover without revalidating
+}
+
+// PagingConfig holds list pagination-related configurations.
+type PagingConfig struct {
+	DefaultPageSize int `mapstructure:"default_page_size"` // Rows of a page when the request sets no limit
+	MaxPageSize     int `mapstructure:"max_page_size"`     // Largest limit a request may set
+}
+
+// SearchConfig holds full-text search-related configurations.
+type SearchConfig struct {
+	// Postgres text search configuration used for stemming, e.g. "english" or "simple".
+	// Migration v18 must be run again after changing it.
+	Language string `mapstructure:"language"`
+}
+
 var AppConfig *Config
 
 // LoadConfig initializes the configuration by reading from environment variables and config files.
@@ -74,9 +114,23 @@ func LoadConfig() (*Config, error) {
 	v.SetDefault("cors.allowed_origins", []string{"http://localhost:3000"})
 	v.SetDefault("cors.allowed_methods", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"})
 	v.SetDefault("cors.allowed_headers", []string{"Origin", "Content-Type", "Authorization"})
-	v.SetDefault("cors.expose_headers", []string{"Content-Length"})
+	v.SetDefault("cors.expose_headers", []string{"Content-Length", "Link", "X-Next-Cursor", "X-Total-Count"})
 	v.SetDefault("cors.allow_credentials", true)
 	v.SetDefault("cors.max_age", 43200) // 12 hours in seconds
+	v.SetDefault("library.loan_period_days", 14)
+	v.SetDefault("library.hold_pickup_days", 3)
+	v.SetDefault("library.max_renewals", 2)
+	v.SetDefault("library.max_loans", 0)
+	v.SetDefault("library.fine_daily_rate", 25)
+	v.SetDefault("library.fine_max_amount", 1000)
+	v.SetDefault("library.fine_block_threshold", 500)
+	v.SetDefault("storage.driver", "local")
+	v.SetDefault("storage.local_dir", "./uploads")
+	v.SetDefault("storage.max_cover_size", 5<<20) // 5 MiB
+	v.SetDefault("storage.cover_cache_max_age", 86400)
+	v.SetDefault("search.language", "english")
+	v.SetDefault("paging.default_page_size", 50)
+	v.SetDefault("paging.max_page_size", 500)
 
 	// Bind environment variables to specific config keys
 	v.BindEnv("database.postgres_url", "POSTGRES_URL")
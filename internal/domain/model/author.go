@@ -1,6 +1,15 @@
 package model
 
+import "borrow_book/internal/domain/response"
+
 type Author struct {
 	ID   int    `db:"id" json:"id"`
 	Name string `db:"name" json:"name"`
 }
+
+func (a *Author) ConvertToResponse() response.AuthorResponse {
+	return response.AuthorResponse{
+		ID:   a.ID,
+		Name: a.Name,
+	}
+}
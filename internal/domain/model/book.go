@@ -5,19 +5,94 @@ import (
 	"time"
 )
 
+// Roles of the contributors of a book.
+const (
+	ContributorRoleAuthor      = "author"
+	ContributorRoleEditor      = "editor"
+	ContributorRoleTranslator  = "translator"
+	ContributorRoleIllustrator = "illustrator"
+)
+
+// Formats of an edition of a book.
+const (
+	BookFormatHardcover = "hardcover"
+	BookFormatPaperback = "paperback"
+	BookFormatEbook     = "ebook"
+	BookFormatAudiobook = "audiobook"
+)
+
 type Book struct {
-	ID          int    `db:"id" json:"id"`
-	Title       string `db:"title" json:"title"`
-	AuthorID    int    `db:"author_id" json:"author_id"`
-	PublishedAt int64  `db:"published_at" json:"published_at"`
+	ID           int               `db:"id" json:"id"`
+	Title        string            `db:"title" json:"title"`
+	ISBN13       string            `db:"isbn13" json:"isbn13"`     // Digits only, empty when the book has no ISBN
+	ISBN10       string            `db:"isbn10" json:"isbn10"`     // Empty for ISBN-13s outside the 978 prefix
+	Category     string            `db:"category" json:"category"` // Selects the loan policies of the book, empty for none
+	PublishedAt  int64             `db:"published_at" json:"published_at"`
+	PublisherID  *int              `db:"publisher_id" json:"publisher_id"` // Nil when the publisher is unknown
+	Edition      string            `db:"edition" json:"edition"`           // Edition statement, e.g. "2nd revised edition"
+	PageCount    int               `db:"page_count" json:"page_count"`     // 0 when unknown
+	Language     string            `db:"language" json:"language"`         // ISO 639 code, e.g. "en"
+	Format       string            `db:"format" json:"format"`             // Empty when unknown
+	Contributors []BookContributor `db:"-" json:"contributors"`            // Ordered by position
+	Genres       []Genre           `db:"-" json:"genres"`
+	Tags         []Tag             `db:"-" json:"tags"`
+	Authors      []Author          `db:"-" json:"authors,omitempty"`   // Distinct contributors, when expanded
+	Publisher    *Publisher        `db:"-" json:"publisher,omitempty"` // When expanded
+}
+
+// BookContributor links an author to a book in a given role.
+type BookContributor struct {
+	BookID   int    `db:"book_id" json:"book_id"`
+	AuthorID int    `db:"author_id" json:"author_id"`
+	Name     string `db:"name" json:"name"` // Name of the author, read only
+	Role     string `db:"role" json:"role"`
+	Position int    `db:"position" json:"position"` // Order of the contributors of the book, starting at 1
 }
 
 func (b *Book) ConvertToResponse() response.BookResponse {
 	tm := time.Unix(b.PublishedAt, 0).UTC() // Convert timestamp to time.Time
-	return response.BookResponse{
-		ID:          b.ID,
-		Title:       b.Title,
-		AuthorID:    b.AuthorID,
-		PublishedAt: tm.Format("2006-01-02"), // Format as "YYYY-MM-DD"
+	contributors := make([]response.BookContributorResponse, len(b.Contributors))
+	for i, c := range b.Contributors {
+		contributors[i] = response.BookContributorResponse{
+			AuthorID: c.AuthorID,
+			Name:     c.Name,
+			Role:     c.Role,
+			Position: c.Position,
+		}
+	}
+	genres := make([]response.GenreResponse, len(b.Genres))
+	for i, g := range b.Genres {
+		genres[i] = g.ConvertToResponse()
+	}
+	tags := make([]response.TagResponse, len(b.Tags))
+	for i, t := range b.Tags {
+		tags[i] = t.ConvertToResponse()
+	}
+	resp := response.BookResponse{
+		ID:           b.ID,
+		Title:        b.Title,
+		ISBN13:       b.ISBN13,
+		ISBN10:       b.ISBN10,
+		Category:     b.Category,
+		PublishedAt:  tm.Format("2006-01-02"), // Format as "YYYY-MM-DD"
+		PublisherID:  b.PublisherID,
+		Edition:      b.Edition,
+		PageCount:    b.PageCount,
+		Language:     b.Language,
+		Format:       b.Format,
+		Contributors: contributors,
+		Genres:       genres,
+		Tags:         tags,
+	}
+	if b.Authors != nil {
+		resp.Authors = make([]response.AuthorResponse, len(b.Authors))
+		for i, a := range b.Authors {
+			resp.Authors[i] = a.ConvertToResponse()
+		}
+	}
+	if b.Publisher != nil {
+		publisher := b.Publisher.ConvertToResponse()
+		resp.Publisher = &publisher
 	}
+	return resp
 }
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"borrow_book/internal/domain/response"
+	"time"
+)
+
+// Statuses of a physical copy.
+const (
+	CopyStatusAvailable   = "available"
+	CopyStatusOnLoan      = "on_loan"
+	CopyStatusOnHold      = "on_hold"    // Held for a ready reservation
+	CopyStatusInTransit   = "in_transit" // Shipped to another branch
+	CopyStatusMaintenance = "maintenance"
+	CopyStatusLost        = "lost"
+)
+
+// BookCopy is a physical item of a book that can be lent out.
+type BookCopy struct {
+	ID         int    `db:"id" json:"id"`
+	BookID     int    `db:"book_id" json:"book_id"`
+	BranchID   int    `db:"branch_id" json:"branch_id"` // Branch holding the copy, or the one it was last at while on loan or in transit
+	Barcode    string `db:"barcode" json:"barcode"`
+	Status     string `db:"status" json:"status"`
+	AcquiredAt int64  `db:"acquired_at" json:"acquired_at"`
+}
+
+func (c *BookCopy) ConvertToResponse() response.BookCopyResponse {
+	tm := time.Unix(c.AcquiredAt, 0).UTC() // Convert timestamp to time.Time
+	return response.BookCopyResponse{
+		ID:         c.ID,
+		BookID:     c.BookID,
+		BranchID:   c.BranchID,
+		Barcode:    c.Barcode,
+		Status:     c.Status,
+		AcquiredAt: tm.Format("2006-01-02"), // Format as "YYYY-MM-DD"
+	}
+}
+
+// BookAvailability summarizes the copies of a book, at a single branch or at all of them.
+type BookAvailability struct {
+	BookID    int  `db:"book_id" json:"book_id"`
+	BranchID  *int `db:"branch_id" json:"branch_id"` // Nil when counting the copies of every branch
+	Total     int  `db:"total" json:"total"`
+	Available int  `db:"available" json:"available"`
+	OnLoan    int  `db:"on_loan" json:"on_loan"`
+	OnHold    int  `db:"on_hold" json:"on_hold"`
+	InTransit int  `db:"in_transit" json:"in_transit"`
+}
+
+func (a *BookAvailability) ConvertToResponse() response.BookAvailabilityResponse {
+	return response.BookAvailabilityResponse{
+		BookID:    a.BookID,
+		BranchID:  a.BranchID,
+		Total:     a.Total,
+		Available: a.Available,
+		OnLoan:    a.OnLoan,
+		OnHold:    a.OnHold,
+		InTransit: a.InTransit,
+	}
+}
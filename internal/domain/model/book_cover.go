@@ -0,0 +1,38 @@
+package model
+
+import (
+	"borrow_book/internal/domain/response"
+	"time"
+)
+
+// Sizes a book cover is served in. The original is kept as uploaded and the
+// other sizes are thumbnails generated on upload.
+const (
+	CoverSizeOriginal = "original"
+	CoverSizeLarge    = "large"
+	CoverSizeMedium   = "medium"
+	CoverSizeSmall    = "small"
+)
+
+// BookCover describes the cover image of a book. The image files are kept in storage.
+type BookCover struct {
+	BookID      int    `db:"book_id" json:"book_id"`
+	ContentType string `db:"content_type" json:"content_type"` // image/jpeg or image/png
+	Width       int    `db:"width" json:"width"`               // Of the original, in pixels
+	Height      int    `db:"height" json:"height"`
+	Size        int64  `db:"size" json:"size"`         // Of the original, in bytes
+	Checksum    string `db:"checksum" json:"checksum"` // SHA-256 of the original, hex encoded
+	UpdatedAt   int64  `db:"updated_at" json:"updated_at"`
+}
+
+func (c *BookCover) ConvertToResponse() response.BookCoverResponse {
+	return response.BookCoverResponse{
+		BookID:      c.BookID,
+		ContentType: c.ContentType,
+		Width:       c.Width,
+		Height:      c.Height,
+		Size:        c.Size,
+		Sizes:       []string{CoverSizeOriginal, CoverSizeLarge, CoverSizeMedium, CoverSizeSmall},
+		UpdatedAt:   time.Unix(c.UpdatedAt, 0).UTC().Format(time.RFC3339),
+	}
+}
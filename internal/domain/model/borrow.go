@@ -5,19 +5,87 @@ import (
 	"time"
 )
 
+// Borrow states derived from the due and return dates.
+const (
+	BorrowStatusOpen     = "open"
+	BorrowStatusReturned = "returned"
+	BorrowStatusOverdue  = "overdue"
+)
+
+// Loans selected in the borrow history of a member. Current loans are the
+// ones not returned yet, overdue or not.
+const (
+	BorrowHistoryCurrent = "current"
+	BorrowHistoryPast    = "past"
+)
+
 type Borrow struct {
-	ID         int    `db:"id" json:"id"`
-	BookID     int    `db:"book_id" json:"book_id"`
-	UserName   string `db:"user_name" json:"user_name"` // Name of the user borrow that book
-	BorrowedAt int64  `db:"borrowed_at" json:"borrowed_at"`
+	ID             int     `db:"id" json:"id"`
+	BookID         int     `db:"book_id" json:"book_id"`
+	CopyID         int     `db:"copy_id" json:"copy_id"`
+	BranchID       int     `db:"branch_id" json:"branch_id"`               // Branch that lent the copy
+	ReturnBranchID *int    `db:"return_branch_id" json:"return_branch_id"` // Branch the copy was returned to, nil while on loan
+	MemberID       int     `db:"member_id" json:"member_id"`               // Member who borrowed the book
+	BorrowedAt     int64   `db:"borrowed_at" json:"borrowed_at"`
+	DueAt          int64   `db:"due_at" json:"due_at"`
+	ReturnedAt     *int64  `db:"returned_at" json:"returned_at"` // Nil while the book is still on loan
+	RenewalCount   int     `db:"renewal_count" json:"renewal_count"`
+	Book           *Book   `db:"-" json:"book,omitempty"`   // When expanded
+	Member         *Member `db:"-" json:"member,omitempty"` // When expanded
+}
+
+// Status reports whether the borrow is open, returned or overdue at the given time.
+func (b *Borrow) Status(now time.Time) string {
+	if b.ReturnedAt != nil {
+		return BorrowStatusReturned
+	}
+	if b.DueAt > 0 && b.DueAt < now.Unix() {
+		return BorrowStatusOverdue
+	}
+	return BorrowStatusOpen
 }
 
 func (b *Borrow) ConvertToResponse() response.BorrowResponse {
 	tm := time.Unix(b.BorrowedAt, 0).UTC() // Convert timestamp to time.Time
-	return response.BorrowResponse{
-		ID:         b.ID,
-		BookID:     b.BookID,
-		UserName:   b.UserName,
-		BorrowedAt: tm.Format("2006-01-02"), // Format as "YYYY-MM-DD"
+	resp := response.BorrowResponse{
+		ID:             b.ID,
+		BookID:         b.BookID,
+		CopyID:         b.CopyID,
+		BranchID:       b.BranchID,
+		ReturnBranchID: b.ReturnBranchID,
+		MemberID:       b.MemberID,
+		BorrowedAt:     tm.Format("2006-01-02"), // Format as "YYYY-MM-DD"
+		DueAt:          time.Unix(b.DueAt, 0).UTC().Format("2006-01-02"),
+		Status:         b.Status(time.Now()),
+		RenewalCount:   b.RenewalCount,
+	}
+	if b.ReturnedAt != nil {
+		returnedAt := time.Unix(*b.ReturnedAt, 0).UTC().Format("2006-01-02")
+		resp.ReturnedAt = &returnedAt
+	}
+	if b.Book != nil {
+		book := b.Book.ConvertToResponse()
+		resp.Book = &book
+	}
+	if b.Member != nil {
+		member := b.Member.ConvertToResponse()
+		resp.Member = &member
+	}
+	return resp
+}
+
+// BorrowHistoryEntry is a loan of a member together with the book it is about.
+type BorrowHistoryEntry struct {
+	Borrow
+	BookTitle  string `db:"book_title" json:"book_title"`
+	AuthorName string `db:"author_name" json:"author_name"`
+}
+
+func (e *BorrowHistoryEntry) ConvertToResponse() response.BorrowHistoryResponse {
+	return response.BorrowHistoryResponse{
+		BorrowResponse: e.Borrow.ConvertToResponse(),
+		BookTitle:      e.BookTitle,
+		AuthorName:     e.AuthorName,
+		Current:        e.ReturnedAt == nil,
 	}
 }
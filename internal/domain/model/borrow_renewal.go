@@ -0,0 +1,34 @@
+package model
+
+import (
+	"borrow_book/internal/domain/response"
+	"time"
+)
+
+// BorrowRenewal records an attempt to extend a loan, successful or not.
+type BorrowRenewal struct {
+	ID            int    `db:"id" json:"id"`
+	BorrowID      int    `db:"borrow_id" json:"borrow_id"`
+	AttemptedAt   int64  `db:"attempted_at" json:"attempted_at"`
+	Succeeded     bool   `db:"succeeded" json:"succeeded"`
+	PreviousDueAt int64  `db:"previous_due_at" json:"previous_due_at"`
+	NewDueAt      *int64 `db:"new_due_at" json:"new_due_at"` // Nil when the renewal was refused
+	Reason        string `db:"reason" json:"reason"`         // Why the renewal was refused
+}
+
+func (r *BorrowRenewal) ConvertToResponse() response.BorrowRenewalResponse {
+	tm := time.Unix(r.AttemptedAt, 0).UTC() // Convert timestamp to time.Time
+	resp := response.BorrowRenewalResponse{
+		ID:            r.ID,
+		BorrowID:      r.BorrowID,
+		AttemptedAt:   tm.Format(time.RFC3339),
+		Succeeded:     r.Succeeded,
+		PreviousDueAt: time.Unix(r.PreviousDueAt, 0).UTC().Format("2006-01-02"),
+		Reason:        r.Reason,
+	}
+	if r.NewDueAt != nil {
+		newDueAt := time.Unix(*r.NewDueAt, 0).UTC().Format("2006-01-02")
+		resp.NewDueAt = &newDueAt
+	}
+	return resp
+}
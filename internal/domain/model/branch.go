@@ -0,0 +1,20 @@
+package model
+
+import "borrow_book/internal/domain/response"
+
+// Branch is a location of the library holding copies and serving loans.
+type Branch struct {
+	ID      int    `db:"id" json:"id"`
+	Code    string `db:"code" json:"code"` // Short unique code printed on transfer slips, e.g. "MAIN"
+	Name    string `db:"name" json:"name"`
+	Address string `db:"address" json:"address"`
+}
+
+func (b *Branch) ConvertToResponse() response.BranchResponse {
+	return response.BranchResponse{
+		ID:      b.ID,
+		Code:    b.Code,
+		Name:    b.Name,
+		Address: b.Address,
+	}
+}
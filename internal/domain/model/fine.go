@@ -0,0 +1,69 @@
+package model
+
+import (
+	"borrow_book/internal/domain/response"
+	"time"
+)
+
+// Kinds of fine ledger entries. Fines add to the balance of a patron,
+// payments and waivers settle it.
+const (
+	FineKindFine    = "fine"
+	FineKindPayment = "payment"
+	FineKindWaiver  = "waiver"
+)
+
+// FineEntry is a line of the fines ledger. Amounts are in cents and always positive.
+type FineEntry struct {
+	ID        int    `db:"id" json:"id"`
+	MemberID  int    `db:"member_id" json:"member_id"`
+	BorrowID  *int   `db:"borrow_id" json:"borrow_id"`
+	Kind      string `db:"kind" json:"kind"`
+	Amount    int64  `db:"amount" json:"amount"`
+	Reason    string `db:"reason" json:"reason"`
+	CreatedAt int64  `db:"created_at" json:"created_at"`
+}
+
+func (f *FineEntry) ConvertToResponse() response.FineEntryResponse {
+	tm := time.Unix(f.CreatedAt, 0).UTC() // Convert timestamp to time.Time
+	return response.FineEntryResponse{
+		ID:        f.ID,
+		MemberID:  f.MemberID,
+		BorrowID:  f.BorrowID,
+		Kind:      f.Kind,
+		Amount:    f.Amount,
+		Reason:    f.Reason,
+		CreatedAt: tm.Format("2006-01-02"), // Format as "YYYY-MM-DD"
+	}
+}
+
+// FineBalance is the amount a patron still owes, in cents.
+type FineBalance struct {
+	MemberID   int    `db:"member_id" json:"member_id"`
+	MemberName string `db:"member_name" json:"member_name"`
+	Balance    int64  `db:"balance" json:"balance"`
+}
+
+func (b *FineBalance) ConvertToResponse() response.FineBalanceResponse {
+	return response.FineBalanceResponse{
+		MemberID:   b.MemberID,
+		MemberName: b.MemberName,
+		Balance:    b.Balance,
+	}
+}
+
+// CalculateFine returns the fine of a loan returned at returnedAt, charging
+// dailyRate for every started day past dueAt up to maxAmount (0 for no cap).
+func CalculateFine(dueAt, returnedAt, dailyRate, maxAmount int64) int64 {
+	const day = int64(24 * time.Hour / time.Second)
+	late := returnedAt - dueAt
+	if late <= 0 {
+		return 0
+	}
+	days := (late + day - 1) / day
+	fine := days * dailyRate
+	if maxAmount > 0 && fine > maxAmount {
+		fine = maxAmount
+	}
+	return fine
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"borrow_book/internal/domain/response"
+	"time"
+)
+
+// LoanPolicy sets the borrowing rules of a member category. A policy with a
+// book category only applies to books of that category, one without applies
+// to every loan of the members.
+type LoanPolicy struct {
+	ID             int    `db:"id" json:"id"`
+	MemberCategory string `db:"member_category" json:"member_category"`
+	BookCategory   string `db:"book_category" json:"book_category"` // Empty for every book
+	MaxLoans       int    `db:"max_loans" json:"max_loans"`         // Loans held at once, 0 for no limit
+	LoanPeriodDays int    `db:"loan_period_days" json:"loan_period_days"`
+	MaxRenewals    int    `db:"max_renewals" json:"max_renewals"`
+}
+
+// LoanPeriod returns the time a loan under the policy lasts.
+func (p *LoanPolicy) LoanPeriod() time.Duration {
+	return time.Duration(p.LoanPeriodDays) * 24 * time.Hour
+}
+
+func (p *LoanPolicy) ConvertToResponse() response.LoanPolicyResponse {
+	return response.LoanPolicyResponse{
+		ID:             p.ID,
+		MemberCategory: p.MemberCategory,
+		BookCategory:   p.BookCategory,
+		MaxLoans:       p.MaxLoans,
+		LoanPeriodDays: p.LoanPeriodDays,
+		MaxRenewals:    p.MaxRenewals,
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"borrow_book/internal/domain/response"
+	"time"
+)
+
+// Statuses of a member. Suspended members keep their history but cannot borrow.
+const (
+	MemberStatusActive    = "active"
+	MemberStatusSuspended = "suspended"
+)
+
+// DefaultMemberCategory is the category of members registered without one.
+const DefaultMemberCategory = "standard"
+
+// Member is a patron of the library.
+type Member struct {
+	ID         int    `db:"id" json:"id"`
+	CardNumber string `db:"card_number" json:"card_number"`
+	Name       string `db:"name" json:"name"`
+	Email      string `db:"email" json:"email"`
+	Phone      string `db:"phone" json:"phone"`
+	Status     string `db:"status" json:"status"`
+	Category   string `db:"category" json:"category"`     // Selects the loan policies of the member, e.g. student or staff
+	ExpiresAt  int64  `db:"expires_at" json:"expires_at"` // End of the membership
+}
+
+// IsExpired reports whether the membership has ended at the given time.
+func (m *Member) IsExpired(now time.Time) bool {
+	return m.ExpiresAt < now.Unix()
+}
+
+func (m *Member) ConvertToResponse() response.MemberResponse {
+	tm := time.Unix(m.ExpiresAt, 0).UTC() // Convert timestamp to time.Time
+	return response.MemberResponse{
+		ID:         m.ID,
+		CardNumber: m.CardNumber,
+		Name:       m.Name,
+		Email:      m.Email,
+		Phone:      m.Phone,
+		Status:     m.Status,
+		Category:   m.Category,
+		ExpiresAt:  tm.Format("2006-01-02"), // Format as "YYYY-MM-DD"
+		Expired:    m.IsExpired(time.Now()),
+	}
+}
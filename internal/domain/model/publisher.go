@@ -0,0 +1,15 @@
+package model
+
+import "borrow_book/internal/domain/response"
+
+type Publisher struct {
+	ID   int    `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
+}
+
+func (p *Publisher) ConvertToResponse() response.PublisherResponse {
+	return response.PublisherResponse{
+		ID:   p.ID,
+		Name: p.Name,
+	}
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"borrow_book/internal/domain/response"
+	"time"
+)
+
+// Statuses of a reservation. Pending reservations wait in the queue of the
+// book, ready ones have a copy held for the patron until they expire.
+const (
+	ReservationStatusPending   = "pending"
+	ReservationStatusReady     = "ready"
+	ReservationStatusFulfilled = "fulfilled"
+	ReservationStatusCancelled = "cancelled"
+	ReservationStatusExpired   = "expired"
+)
+
+// Reservation is a hold placed by a patron on a book whose copies are all out.
+type Reservation struct {
+	ID        int    `db:"id" json:"id"`
+	BookID    int    `db:"book_id" json:"book_id"`
+	MemberID  int    `db:"member_id" json:"member_id"`
+	Status    string `db:"status" json:"status"`
+	CopyID    *int   `db:"copy_id" json:"copy_id"` // Copy held for the patron once the reservation is ready
+	CreatedAt int64  `db:"created_at" json:"created_at"`
+	ReadyAt   *int64 `db:"ready_at" json:"ready_at"`
+	ExpiresAt *int64 `db:"expires_at" json:"expires_at"` // Pickup deadline of a ready reservation
+	Position  int    `db:"position" json:"position"`     // Place in the queue, only set for pending reservations
+}
+
+// IsActive reports whether the reservation still waits for or holds a copy.
+func (r *Reservation) IsActive() bool {
+	return r.Status == ReservationStatusPending || r.Status == ReservationStatusReady
+}
+
+func (r *Reservation) ConvertToResponse() response.ReservationResponse {
+	tm := time.Unix(r.CreatedAt, 0).UTC() // Convert timestamp to time.Time
+	resp := response.ReservationResponse{
+		ID:        r.ID,
+		BookID:    r.BookID,
+		MemberID:  r.MemberID,
+		Status:    r.Status,
+		Position:  r.Position,
+		CopyID:    r.CopyID,
+		CreatedAt: tm.Format("2006-01-02"), // Format as "YYYY-MM-DD"
+	}
+	if r.ReadyAt != nil {
+		readyAt := time.Unix(*r.ReadyAt, 0).UTC().Format("2006-01-02")
+		resp.ReadyAt = &readyAt
+	}
+	if r.ExpiresAt != nil {
+		expiresAt := time.Unix(*r.ExpiresAt, 0).UTC().Format("2006-01-02")
+		resp.ExpiresAt = &expiresAt
+	}
+	return resp
+}
@@ -0,0 +1,28 @@
+package model
+
+import "borrow_book/internal/domain/response"
+
+// Kinds of records returned by a search.
+const (
+	SearchTypeBook   = "book"
+	SearchTypeAuthor = "author"
+)
+
+// SearchHit is a book or author matching a full-text search.
+type SearchHit struct {
+	Type      string  `db:"type" json:"type"`
+	ID        int     `db:"id" json:"id"`
+	Name      string  `db:"name" json:"name"`           // Title of a book or name of an author
+	Highlight string  `db:"highlight" json:"highlight"` // Name with the matching words wrapped in <mark> tags
+	Rank      float64 `db:"rank" json:"rank"`
+}
+
+func (h *SearchHit) ConvertToResponse() response.SearchHitResponse {
+	return response.SearchHitResponse{
+		Type:      h.Type,
+		ID:        h.ID,
+		Name:      h.Name,
+		Highlight: h.Highlight,
+		Rank:      h.Rank,
+	}
+}
@@ -0,0 +1,31 @@
+package model
+
+import "borrow_book/internal/domain/response"
+
+// Genre is a node of the genre and subject hierarchy. Top-level genres have no parent.
+type Genre struct {
+	ID       int    `db:"id" json:"id"`
+	Name     string `db:"name" json:"name"`
+	ParentID *int   `db:"parent_id" json:"parent_id"`
+}
+
+func (g *Genre) ConvertToResponse() response.GenreResponse {
+	return response.GenreResponse{
+		ID:       g.ID,
+		Name:     g.Name,
+		ParentID: g.ParentID,
+	}
+}
+
+// Tag is a free-form label of books. Names are stored lower case.
+type Tag struct {
+	ID   int    `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
+}
+
+func (t *Tag) ConvertToResponse() response.TagResponse {
+	return response.TagResponse{
+		ID:   t.ID,
+		Name: t.Name,
+	}
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"borrow_book/internal/domain/response"
+	"time"
+)
+
+// Statuses of a transfer. Transfers go from requested to in_transit to
+// received, or are cancelled before they are shipped.
+const (
+	TransferStatusRequested = "requested"
+	TransferStatusInTransit = "in_transit"
+	TransferStatusReceived  = "received"
+	TransferStatusCancelled = "cancelled"
+)
+
+// Transfer moves a copy from one branch to another.
+type Transfer struct {
+	ID           int    `db:"id" json:"id"`
+	CopyID       int    `db:"copy_id" json:"copy_id"`
+	FromBranchID int    `db:"from_branch_id" json:"from_branch_id"`
+	ToBranchID   int    `db:"to_branch_id" json:"to_branch_id"`
+	Status       string `db:"status" json:"status"`
+	RequestedAt  int64  `db:"requested_at" json:"requested_at"`
+	ShippedAt    *int64 `db:"shipped_at" json:"shipped_at"`   // Nil until the copy leaves its branch
+	ReceivedAt   *int64 `db:"received_at" json:"received_at"` // Nil until the copy arrives
+	Note         string `db:"note" json:"note"`
+}
+
+func (t *Transfer) ConvertToResponse() response.TransferResponse {
+	return response.TransferResponse{
+		ID:           t.ID,
+		CopyID:       t.CopyID,
+		FromBranchID: t.FromBranchID,
+		ToBranchID:   t.ToBranchID,
+		Status:       t.Status,
+		RequestedAt:  time.Unix(t.RequestedAt, 0).UTC().Format(time.RFC3339),
+		ShippedAt:    formatOptionalTime(t.ShippedAt),
+		ReceivedAt:   formatOptionalTime(t.ReceivedAt),
+		Note:         t.Note,
+	}
+}
+
+// TransferEvent records a step of a transfer, for the audit trail.
+type TransferEvent struct {
+	ID         int    `db:"id" json:"id"`
+	TransferID int    `db:"transfer_id" json:"transfer_id"`
+	Status     string `db:"status" json:"status"` // Status the transfer entered
+	OccurredAt int64  `db:"occurred_at" json:"occurred_at"`
+	Note       string `db:"note" json:"note"`
+}
+
+func (e *TransferEvent) ConvertToResponse() response.TransferEventResponse {
+	return response.TransferEventResponse{
+		ID:         e.ID,
+		TransferID: e.TransferID,
+		Status:     e.Status,
+		OccurredAt: time.Unix(e.OccurredAt, 0).UTC().Format(time.RFC3339),
+		Note:       e.Note,
+	}
+}
+
+func formatOptionalTime(ts *int64) *string {
+	if ts == nil {
+		return nil
+	}
+	formatted := time.Unix(*ts, 0).UTC().Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,13 @@
+package request
+
+type CreateBookCopyRequest struct {
+	BranchID   int    `json:"branch_id" binding:"required"`
+	Barcode    string `json:"barcode" binding:"required"`
+	AcquiredAt string `json:"acquired_at" binding:"required"` // Expected format: "YYYY-MM-DD"
+}
+
+type UpdateBookCopyRequest struct {
+	Barcode    string `json:"barcode" binding:"required"`
+	Status     string `json:"status" binding:"required"`
+	AcquiredAt string `json:"acquired_at" binding:"required"` // Expected format: "YYYY-MM-DD"
+}
@@ -1,9 +1,23 @@
 package request
 
 type BaseBookRequest struct {
-	Title       string `json:"title"`
-	AuthorID    int    `json:"author_id"`
-	PublishedAt string `json:"published_at"` // Expected format: "YYYY-MM-DD"
+	Title        string                   `json:"title"`
+	ISBN         string                   `json:"isbn"`         // Optional ISBN-10 or ISBN-13, hyphens allowed
+	Category     string                   `json:"category"`     // Optional, e.g. reference or media
+	PublishedAt  string                   `json:"published_at"` // Expected format: "YYYY-MM-DD"
+	PublisherID  *int                     `json:"publisher_id"`
+	Edition      string                   `json:"edition"` // Optional, e.g. "2nd revised edition"
+	PageCount    int                      `json:"page_count" binding:"min=0"`
+	Language     string                   `json:"language"` // Optional ISO 639 code, e.g. "en"
+	Format       string                   `json:"format"`   // Optional: hardcover, paperback, ebook or audiobook
+	Contributors []BookContributorRequest `json:"contributors" binding:"required,min=1,dive"`
+}
+
+// BookContributorRequest is an author of the book in a role. Contributors are
+// ordered as listed.
+type BookContributorRequest struct {
+	AuthorID int    `json:"author_id" binding:"required"`
+	Role     string `json:"role"` // Defaults to "author"
 }
 
 type CreateBookRequest struct {
@@ -2,12 +2,19 @@ package request
 
 type BaseBorrowRequest struct {
 	BookID     int    `json:"book_id"`
-	UserName   string `json:"user_name"`
+	MemberID   int    `json:"member_id" binding:"required"`
 	BorrowedAt string `json:"borrowed_at"`
 }
 
 type CreateBorrowRequest struct {
 	BaseBorrowRequest
+	CopyID   int `json:"copy_id"`   // Optional, any available copy of the book is lent when omitted
+	BranchID int `json:"branch_id"` // Optional, branch lending the book. Copies of any branch are lent when omitted
+}
+
+// ReturnBorrowRequest is the optional body of a return.
+type ReturnBorrowRequest struct {
+	BranchID int `json:"branch_id"` // Branch the copy is returned to, defaults to the branch that lent it
 }
 
 type UpdateBorrowRequest struct {
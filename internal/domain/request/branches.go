@@ -0,0 +1,15 @@
+package request
+
+type BaseBranchRequest struct {
+	Code    string `json:"code" binding:"required"`
+	Name    string `json:"name" binding:"required"`
+	Address string `json:"address"`
+}
+
+type CreateBranchRequest struct {
+	BaseBranchRequest
+}
+
+type UpdateBranchRequest struct {
+	BaseBranchRequest
+}
@@ -0,0 +1,14 @@
+package request
+
+type FinePaymentRequest struct {
+	MemberID int    `json:"member_id" binding:"required"`
+	Amount   int64  `json:"amount" binding:"required,gt=0"` // In cents
+	Reason   string `json:"reason"`
+}
+
+type FineWaiverRequest struct {
+	MemberID int    `json:"member_id" binding:"required"`
+	Amount   int64  `json:"amount" binding:"required,gt=0"` // In cents
+	Reason   string `json:"reason" binding:"required"`
+	BorrowID *int   `json:"borrow_id"`
+}
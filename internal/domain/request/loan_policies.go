@@ -0,0 +1,17 @@
+package request
+
+type BaseLoanPolicyRequest struct {
+	MemberCategory string `json:"member_category" binding:"required"`
+	BookCategory   string `json:"book_category"` // Optional, the policy applies to every book when omitted
+	MaxLoans       int    `json:"max_loans"`     // 0 for no limit
+	LoanPeriodDays int    `json:"loan_period_days" binding:"required"`
+	MaxRenewals    int    `json:"max_renewals"`
+}
+
+type CreateLoanPolicyRequest struct {
+	BaseLoanPolicyRequest
+}
+
+type UpdateLoanPolicyRequest struct {
+	BaseLoanPolicyRequest
+}
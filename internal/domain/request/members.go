@@ -0,0 +1,19 @@
+package request
+
+type BaseMemberRequest struct {
+	CardNumber string `json:"card_number" binding:"required"`
+	Name       string `json:"name" binding:"required"`
+	Email      string `json:"email"`
+	Phone      string `json:"phone"`
+	Category   string `json:"category"`                      // Defaults to "standard"
+	ExpiresAt  string `json:"expires_at" binding:"required"` // Expected format: "YYYY-MM-DD"
+}
+
+type CreateMemberRequest struct {
+	BaseMemberRequest
+}
+
+type UpdateMemberRequest struct {
+	BaseMemberRequest
+	Status string `json:"status" binding:"required"` // active or suspended
+}
@@ -0,0 +1,9 @@
+package request
+
+type CreatePublisherRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type UpdatePublisherRequest struct {
+	Name string `json:"name" binding:"required"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type CreateReservationRequest struct {
+	MemberID int `json:"member_id" binding:"required"`
+}
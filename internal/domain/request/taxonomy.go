@@ -0,0 +1,31 @@
+package request
+
+type BaseGenreRequest struct {
+	Name     string `json:"name" binding:"required"`
+	ParentID *int   `json:"parent_id"` // Optional, the genre is top-level when omitted
+}
+
+type CreateGenreRequest struct {
+	BaseGenreRequest
+}
+
+type UpdateGenreRequest struct {
+	BaseGenreRequest
+}
+
+type BaseTagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type CreateTagRequest struct {
+	BaseTagRequest
+}
+
+type UpdateTagRequest struct {
+	BaseTagRequest
+}
+
+// BookTagRequest tags a book, creating the tag when it does not exist yet.
+type BookTagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
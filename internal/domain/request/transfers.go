@@ -0,0 +1,12 @@
+package request
+
+type CreateTransferRequest struct {
+	CopyID     int    `json:"copy_id" binding:"required"`
+	ToBranchID int    `json:"to_branch_id" binding:"required"`
+	Note       string `json:"note"`
+}
+
+// TransferStepRequest is the optional body of the ship, receive and cancel steps of a transfer.
+type TransferStepRequest struct {
+	Note string `json:"note"`
+}
@@ -0,0 +1,20 @@
+package response
+
+type BookCopyResponse struct {
+	ID         int    `json:"id"`
+	BookID     int    `json:"book_id"`
+	BranchID   int    `json:"branch_id"`
+	Barcode    string `json:"barcode"`
+	Status     string `json:"status"`
+	AcquiredAt string `json:"acquired_at"` // Format: "YYYY-MM-DD"
+}
+
+type BookAvailabilityResponse struct {
+	BookID    int  `json:"book_id"`
+	BranchID  *int `json:"branch_id,omitempty"`
+	Total     int  `json:"total"`
+	Available int  `json:"available"`
+	OnLoan    int  `json:"on_loan"`
+	OnHold    int  `json:"on_hold"`
+	InTransit int  `json:"in_transit"`
+}
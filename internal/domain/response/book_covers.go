@@ -0,0 +1,11 @@
+package response
+
+type BookCoverResponse struct {
+	BookID      int      `json:"book_id"`
+	ContentType string   `json:"content_type"`
+	Width       int      `json:"width"`
+	Height      int      `json:"height"`
+	Size        int64    `json:"size"`  // In bytes
+	Sizes       []string `json:"sizes"` // Values of the size parameter of GET /books/{id}/cover
+	UpdatedAt   string   `json:"updated_at"`
+}
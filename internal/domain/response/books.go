@@ -1,8 +1,27 @@
 package response
 
 type BookResponse struct {
-	ID          int    `json:"id"`
-	Title       string `json:"title"`
-	AuthorID    int    `json:"author_id"`
-	PublishedAt string `json:"published_at"` // Format: "YYYY-MM-DD"
+	ID           int                       `json:"id"`
+	Title        string                    `json:"title"`
+	ISBN13       string                    `json:"isbn13,omitempty"`
+	ISBN10       string                    `json:"isbn10,omitempty"`
+	Category     string                    `json:"category"`
+	PublishedAt  string                    `json:"published_at"` // Format: "YYYY-MM-DD"
+	PublisherID  *int                      `json:"publisher_id,omitempty"`
+	Edition      string                    `json:"edition"`
+	PageCount    int                       `json:"page_count"`
+	Language     string                    `json:"language"`
+	Format       string                    `json:"format"` // hardcover, paperback, ebook or audiobook
+	Contributors []BookContributorResponse `json:"contributors"`
+	Genres       []GenreResponse           `json:"genres"`
+	Tags         []TagResponse             `json:"tags"`
+	Authors      []AuthorResponse          `json:"authors,omitempty"`   // With expand=author
+	Publisher    *PublisherResponse        `json:"publisher,omitempty"` // With expand=publisher
+}
+
+type BookContributorResponse struct {
+	AuthorID int    `json:"author_id"`
+	Name     string `json:"name"`
+	Role     string `json:"role"` // author, editor, translator or illustrator
+	Position int    `json:"position"`
 }
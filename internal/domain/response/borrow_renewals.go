@@ -0,0 +1,11 @@
+package response
+
+type BorrowRenewalResponse struct {
+	ID            int     `json:"id"`
+	BorrowID      int     `json:"borrow_id"`
+	AttemptedAt   string  `json:"attempted_at"` // Format: RFC 3339
+	Succeeded     bool    `json:"succeeded"`
+	PreviousDueAt string  `json:"previous_due_at"`      // Format: "YYYY-MM-DD"
+	NewDueAt      *string `json:"new_due_at,omitempty"` // Format: "YYYY-MM-DD"
+	Reason        string  `json:"reason,omitempty"`
+}
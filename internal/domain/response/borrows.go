@@ -1,8 +1,32 @@
 package response
 
 type BorrowResponse struct {
-	ID         int    `json:"id"`
-	BookID     int    `json:"book_id"`
-	UserName   string `json:"user_name"`
-	BorrowedAt string `json:"borrowed_at"` // Format: "YYYY-MM-DD"
+	ID             int             `json:"id"`
+	BookID         int             `json:"book_id"`
+	CopyID         int             `json:"copy_id"`
+	BranchID       int             `json:"branch_id"`
+	ReturnBranchID *int            `json:"return_branch_id,omitempty"`
+	MemberID       int             `json:"member_id"`
+	BorrowedAt     string          `json:"borrowed_at"`           // Format: "YYYY-MM-DD"
+	DueAt          string          `json:"due_at"`                // Format: "YYYY-MM-DD"
+	ReturnedAt     *string         `json:"returned_at,omitempty"` // Format: "YYYY-MM-DD"
+	Status         string          `json:"status"`                // open, returned or overdue
+	RenewalCount   int             `json:"renewal_count"`
+	Book           *BookResponse   `json:"book,omitempty"`   // With expand=book
+	Member         *MemberResponse `json:"member,omitempty"` // With expand=member
+}
+
+type BorrowHistoryResponse struct {
+	BorrowResponse
+	BookTitle  string `json:"book_title"`
+	AuthorName string `json:"author_name"` // Authors of the book, comma separated
+	Current    bool   `json:"current"`     // True while the book is still on loan
+}
+
+// BorrowHistoryPageResponse is a page of the borrow history of a member.
+type BorrowHistoryPageResponse struct {
+	Items    []BorrowHistoryResponse `json:"items"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+	Total    int                     `json:"total"` // Loans over all pages
 }
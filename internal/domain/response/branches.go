@@ -0,0 +1,8 @@
+package response
+
+type BranchResponse struct {
+	ID      int    `json:"id"`
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
@@ -0,0 +1,17 @@
+package response
+
+type FineEntryResponse struct {
+	ID        int    `json:"id"`
+	MemberID  int    `json:"member_id"`
+	BorrowID  *int   `json:"borrow_id,omitempty"`
+	Kind      string `json:"kind"`   // fine, payment or waiver
+	Amount    int64  `json:"amount"` // In cents
+	Reason    string `json:"reason"`
+	CreatedAt string `json:"created_at"` // Format: "YYYY-MM-DD"
+}
+
+type FineBalanceResponse struct {
+	MemberID   int    `json:"member_id"`
+	MemberName string `json:"member_name"`
+	Balance    int64  `json:"balance"` // In cents
+}
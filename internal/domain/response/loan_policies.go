@@ -0,0 +1,21 @@
+package response
+
+type LoanPolicyResponse struct {
+	ID             int    `json:"id"`
+	MemberCategory string `json:"member_category"`
+	BookCategory   string `json:"book_category"` // Empty for every book
+	MaxLoans       int    `json:"max_loans"`     // 0 for no limit
+	LoanPeriodDays int    `json:"loan_period_days"`
+	MaxRenewals    int    `json:"max_renewals"`
+}
+
+// PolicyViolationResponse explains which loan policy rule refused a request.
+type PolicyViolationResponse struct {
+	Error          string `json:"error"`
+	Rule           string `json:"rule"`      // max_loans or max_renewals
+	PolicyID       int    `json:"policy_id"` // 0 for the library defaults
+	MemberCategory string `json:"member_category"`
+	BookCategory   string `json:"book_category"`
+	Limit          int    `json:"limit"`
+	Current        int    `json:"current"`
+}
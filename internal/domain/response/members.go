@@ -0,0 +1,13 @@
+package response
+
+type MemberResponse struct {
+	ID         int    `json:"id"`
+	CardNumber string `json:"card_number"`
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Phone      string `json:"phone"`
+	Status     string `json:"status"` // active or suspended
+	Category   string `json:"category"`
+	ExpiresAt  string `json:"expires_at"` // Format: "YYYY-MM-DD"
+	Expired    bool   `json:"expired"`
+}
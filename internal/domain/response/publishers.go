@@ -0,0 +1,6 @@
+package response
+
+type PublisherResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
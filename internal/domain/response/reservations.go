@@ -0,0 +1,13 @@
+package response
+
+type ReservationResponse struct {
+	ID        int     `json:"id"`
+	BookID    int     `json:"book_id"`
+	MemberID  int     `json:"member_id"`
+	Status    string  `json:"status"`             // pending, ready, fulfilled, cancelled or expired
+	Position  int     `json:"position,omitempty"` // Place in the queue of pending reservations
+	CopyID    *int    `json:"copy_id,omitempty"`
+	CreatedAt string  `json:"created_at"`           // Format: "YYYY-MM-DD"
+	ReadyAt   *string `json:"ready_at,omitempty"`   // Format: "YYYY-MM-DD"
+	ExpiresAt *string `json:"expires_at,omitempty"` // Format: "YYYY-MM-DD"
+}
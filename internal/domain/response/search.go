@@ -0,0 +1,9 @@
+package response
+
+type SearchHitResponse struct {
+	Type      string  `json:"type"` // book or author
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Highlight string  `json:"highlight"` // Name with the matching words wrapped in <mark> tags
+	Rank      float64 `json:"rank"`
+}
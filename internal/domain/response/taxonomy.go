@@ -0,0 +1,12 @@
+package response
+
+type GenreResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ParentID *int   `json:"parent_id,omitempty"` // Omitted for top-level genres
+}
+
+type TagResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
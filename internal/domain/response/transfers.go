@@ -0,0 +1,21 @@
+package response
+
+type TransferResponse struct {
+	ID           int     `json:"id"`
+	CopyID       int     `json:"copy_id"`
+	FromBranchID int     `json:"from_branch_id"`
+	ToBranchID   int     `json:"to_branch_id"`
+	Status       string  `json:"status"`                // requested, in_transit, received or cancelled
+	RequestedAt  string  `json:"requested_at"`          // Format: RFC 3339
+	ShippedAt    *string `json:"shipped_at,omitempty"`  // Format: RFC 3339
+	ReceivedAt   *string `json:"received_at,omitempty"` // Format: RFC 3339
+	Note         string  `json:"note,omitempty"`
+}
+
+type TransferEventResponse struct {
+	ID         int    `json:"id"`
+	TransferID int    `json:"transfer_id"`
+	Status     string `json:"status"`
+	OccurredAt string `json:"occurred_at"` // Format: RFC 3339
+	Note       string `json:"note,omitempty"`
+}
@@ -27,9 +27,17 @@ func NewAuthorHandler(svc service.AuthorService) *AuthorHandler {
 // @Accept json
 // @Produce json
 // @Param filter query []string false "Filter conditions"
+// @Param q query string false "RSQL filter expression joined to the filter conditions, e.g. name=startswith=tol,id=in=(3,5)"
 // @Param sort query []string false "Sort conditions"
-// @Param fields query string false "Fields to select"
+// @Param fields query string false "Comma separated fields to select, the only ones returned"
+// @Param limit query int false "Rows per page, up to the configured maximum"
+// @Param offset query int false "Rows to skip, cannot be combined with cursor"
+// @Param cursor query string false "X-Next-Cursor of the previous page"
+// @Param total query bool false "Return the number of matching rows in X-Total-Count"
 // @Success 200 {array} response.AuthorResponse
+// @Header 200 {string} Link "Link to the next page"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Header 200 {integer} X-Total-Count "Number of matching rows, when requested"
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /authors [get]
@@ -37,12 +45,18 @@ func (h *AuthorHandler) ListAuthors(c *gin.Context) {
 	filters := c.QueryArray("filter")
 	sorts := c.QueryArray("sort")
 	fields := c.Query("fields")
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
 
-	authors, err := h.svc.ListAuthors(c.Request.Context(), filters, sorts, fields)
+	authors, info, err := h.svc.ListAuthors(c.Request.Context(), filters, sorts, fields, c.Query("q"), page)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
+	writePageHeaders(c, info)
 
 	// Convert model.Author to response.AuthorResponse
 	var authorResponses []response.AuthorResponse
@@ -53,7 +67,7 @@ func (h *AuthorHandler) ListAuthors(c *gin.Context) {
 		})
 	}
 
-	c.JSON(http.StatusOK, authorResponses)
+	writeList(c, authorResponses, info.Fields)
 }
 
 // GetAuthor godoc
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"errors"
+	"io"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindOptionalJSON binds the JSON body of the request into obj, leaving obj
+// untouched when the request has no body.
+func bindOptionalJSON(c *gin.Context, obj interface{}) error {
+	err := c.ShouldBindJSON(obj)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/request"
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BookCopyHandler handles HTTP requests for physical copies of books.
+type BookCopyHandler struct {
+	svc service.BookCopyService
+}
+
+// NewBookCopyHandler creates a new BookCopyHandler.
+func NewBookCopyHandler(svc service.BookCopyService) *BookCopyHandler {
+	return &BookCopyHandler{svc: svc}
+}
+
+// ListBookCopies godoc
+// @Summary List copies of a book
+// @Description Get every physical copy of a book
+// @Tags Copies
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Success 200 {array} response.BookCopyResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Book not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/{id}/copies [get]
+func (h *BookCopyHandler) ListBookCopies(c *gin.Context) {
+	idStr := c.Param("id")
+	bookID, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	copies, err := h.svc.ListBookCopies(c.Request.Context(), bookID)
+	if err != nil {
+		if errors.Is(err, service.ErrBookNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.BookCopyResponse, len(copies))
+	for i, cp := range copies {
+		resp[i] = cp.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateBookCopy godoc
+// @Summary Add a copy of a book
+// @Description Register a new physical copy of a book at a branch
+// @Tags Copies
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param copy body request.CreateBookCopyRequest true "Copy to create"
+// @Success 201 {object} response.BookCopyResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 404 {object} response.ErrorResponse "Book or branch not found"
+// @Failure 409 {object} response.ErrorResponse "Barcode already in use"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/{id}/copies [post]
+func (h *BookCopyHandler) CreateBookCopy(c *gin.Context) {
+	idStr := c.Param("id")
+	bookID, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.CreateBookCopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Parse the date string to UNIX timestamp
+	tm, err := time.Parse("2006-01-02", req.AcquiredAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid acquired_at format"})
+		return
+	}
+	timestamp := tm.Unix()
+
+	bookCopy, err := h.svc.CreateCopy(c.Request.Context(), bookID, req.BranchID, req.Barcode, timestamp)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBookNotFound), errors.Is(err, service.ErrBranchNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrDuplicateBarcode):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	resp := bookCopy.ConvertToResponse()
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetBookAvailability godoc
+// @Summary Get the availability of a book
+// @Description Count the total, available, on-loan, on-hold and in-transit copies of a book, at all branches or at a single one
+// @Tags Copies
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param branch_id query int false "Only count the copies at this branch"
+// @Success 200 {object} response.BookAvailabilityResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Book or branch not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/{id}/availability [get]
+func (h *BookCopyHandler) GetBookAvailability(c *gin.Context) {
+	idStr := c.Param("id")
+	bookID, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	branchID := 0
+	if v := c.Query("branch_id"); v != "" {
+		branchID, err = strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid branch_id"})
+			return
+		}
+	}
+
+	availability, err := h.svc.GetAvailability(c.Request.Context(), bookID, branchID)
+	if err != nil {
+		if errors.Is(err, service.ErrBookNotFound) || errors.Is(err, service.ErrBranchNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := availability.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetCopy godoc
+// @Summary Get a copy by ID
+// @Description Retrieve a single physical copy using its unique ID
+// @Tags Copies
+// @Accept json
+// @Produce json
+// @Param id path int true "Copy ID"
+// @Success 200 {object} response.BookCopyResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /copies/{id} [get]
+func (h *BookCopyHandler) GetCopy(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	bookCopy, err := h.svc.GetCopy(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if bookCopy == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "not found"})
+		return
+	}
+
+	resp := bookCopy.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateCopy godoc
+// @Summary Update an existing copy
+// @Description Modify the barcode, status or acquisition date of a copy. Copies on loan, on hold or in transit keep their status until returned, picked up or received. Transfers move copies between branches.
+// @Tags Copies
+// @Accept json
+// @Produce json
+// @Param id path int true "Copy ID"
+// @Param copy body request.UpdateBookCopyRequest true "Copy data to update"
+// @Success 200 {object} response.BookCopyResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 404 {object} response.ErrorResponse "Copy not found"
+// @Failure 409 {object} response.ErrorResponse "Copy on loan, on hold, in transit or barcode already in use"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /copies/{id} [put]
+func (h *BookCopyHandler) UpdateCopy(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.UpdateBookCopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Parse the date string to UNIX timestamp
+	tm, err := time.Parse("2006-01-02", req.AcquiredAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid acquired_at format"})
+		return
+	}
+	timestamp := tm.Unix()
+
+	bookCopy, err := h.svc.UpdateCopy(c.Request.Context(), id, req.Barcode, req.Status, timestamp)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCopyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrInvalidCopyStatus):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrCopyOnLoan), errors.Is(err, service.ErrCopyOnHold),
+			errors.Is(err, service.ErrCopyInTransit), errors.Is(err, service.ErrDuplicateBarcode):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	resp := bookCopy.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteCopy godoc
+// @Summary Delete a copy
+// @Description Remove a physical copy that is not on loan, on hold or in transit and has never been borrowed or transferred
+// @Tags Copies
+// @Accept json
+// @Produce json
+// @Param id path int true "Copy ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Copy not found"
+// @Failure 409 {object} response.ErrorResponse "Copy on loan, on hold, in transit or with borrow or transfer history"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /copies/{id} [delete]
+func (h *BookCopyHandler) DeleteCopy(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	err = h.svc.DeleteCopy(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCopyNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrCopyOnLoan), errors.Is(err, service.ErrCopyOnHold),
+			errors.Is(err, service.ErrCopyInTransit), errors.Is(err, service.ErrCopyHasBorrows):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
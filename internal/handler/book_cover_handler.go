@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left for the multipart headers of a cover upload.
+const multipartOverhead = 64 << 10
+
+// BookCoverHandler handles the HTTP requests on book cover images.
+type BookCoverHandler struct {
+	svc          service.BookCoverService
+	maxSize      int64
+	cacheControl string
+}
+
+// NewBookCoverHandler creates a new BookCoverHandler.
+func NewBookCoverHandler(svc service.BookCoverService, cfg *config.Config) *BookCoverHandler {
+	return &BookCoverHandler{
+		svc:          svc,
+		maxSize:      cfg.Storage.MaxCoverSize,
+		cacheControl: fmt.Sprintf("public, max-age=%d", cfg.Storage.CoverCacheMaxAge),
+	}
+}
+
+// UploadCover godoc
+// @Summary Upload the cover of a book
+// @Description Replace the cover of a book with a JPEG or PNG image sent as the "cover" field of a multipart form. The type is detected from the content of the file, and the large, medium and small thumbnails are generated from it.
+// @Tags Books
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param cover formData file true "JPEG or PNG image"
+// @Success 200 {object} response.BookCoverResponse
+// @Failure 400 {object} response.ErrorResponse "Missing file or invalid image"
+// @Failure 404 {object} response.ErrorResponse "Book not found"
+// @Failure 413 {object} response.ErrorResponse "Image too large"
+// @Failure 415 {object} response.ErrorResponse "Image is neither a JPEG nor a PNG"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/{id}/cover [put]
+func (h *BookCoverHandler) UploadCover(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+multipartOverhead)
+	fileHeader, err := c.FormFile("cover")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.writeError(c, service.ErrCoverTooLarge)
+			return
+		}
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "missing cover file: " + err.Error()})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	defer file.Close()
+
+	// Read one byte past the limit so the service can tell the upload is too large
+	data, err := io.ReadAll(io.LimitReader(file, h.maxSize+1))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	cover, err := h.svc.UploadCover(c.Request.Context(), id, data)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := cover.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetCover godoc
+// @Summary Get the cover of a book
+// @Description Serve the cover image of a book in the original size or as a thumbnail. Responses carry ETag, Last-Modified and Cache-Control headers and conditional requests are answered with 304 Not Modified.
+// @Tags Books
+// @Produce image/jpeg
+// @Produce image/png
+// @Param id path int true "Book ID"
+// @Param size query string false "original (default), large, medium or small"
+// @Success 200 {file} file
+// @Success 304 "Not Modified"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID or size"
+// @Failure 404 {object} response.ErrorResponse "Book or cover not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/{id}/cover [get]
+func (h *BookCoverHandler) GetCover(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+	size := c.DefaultQuery("size", "original")
+
+	cover, obj, err := h.svc.GetCover(c.Request.Context(), id, size)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	defer obj.Body.Close()
+
+	content, ok := obj.Body.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(obj.Body)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		content = bytes.NewReader(data)
+	}
+
+	// Every size changes with the original, so its checksum identifies the content
+	c.Header("ETag", fmt.Sprintf(`"%s-%s"`, cover.Checksum, size))
+	c.Header("Cache-Control", h.cacheControl)
+	c.Header("Content-Type", cover.ContentType)
+	http.ServeContent(c.Writer, c.Request, "", time.Unix(cover.UpdatedAt, 0), content)
+}
+
+func (h *BookCoverHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrBookNotFound), errors.Is(err, service.ErrCoverNotFound):
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrInvalidCoverImage), errors.Is(err, service.ErrInvalidCoverSize):
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrCoverTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrUnsupportedCoverType):
+		c.JSON(http.StatusUnsupportedMediaType, response.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"borrow_book/internal/domain/model"
 	"borrow_book/internal/domain/request"
 	"borrow_book/internal/domain/response"
+	"borrow_book/internal/infra/database/query"
 	"borrow_book/internal/service"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -23,14 +26,23 @@ func NewBookHandler(svc service.BookService) *BookHandler {
 
 // ListBooks godoc
 // @Summary List books
-// @Description Get a list of books with optional filters, sorts, and selected fields
+// @Description Get a list of books with optional filters, sorts, and selected fields. Books can be filtered on the author_id of their contributors, on genre_id (sub-genres included), on tag and on the edition fields publisher_id, edition, page_count, language and format.
 // @Tags Books
 // @Accept json
 // @Produce json
 // @Param filter query []string false "Filter conditions"
+// @Param q query string false "RSQL filter expression joined to the filter conditions, e.g. (author_id==3,author_id==5);published_at=gt=946684800"
 // @Param sort query []string false "Sort conditions"
-// @Param fields query string false "Fields to select"
+// @Param fields query string false "Comma separated fields to select, the only ones returned along with expanded relations"
+// @Param expand query string false "Relations to embed, comma separated: author, publisher"
+// @Param limit query int false "Rows per page, up to the configured maximum"
+// @Param offset query int false "Rows to skip, cannot be combined with cursor"
+// @Param cursor query string false "X-Next-Cursor of the previous page"
+// @Param total query bool false "Return the number of matching rows in X-Total-Count"
 // @Success 200 {array} response.BookResponse
+// @Header 200 {string} Link "Link to the next page"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Header 200 {integer} X-Total-Count "Number of matching rows, when requested"
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /books [get]
@@ -38,12 +50,18 @@ func (h *BookHandler) ListBooks(c *gin.Context) {
 	filters := c.QueryArray("filter")
 	sorts := c.QueryArray("sort")
 	fields := c.Query("fields")
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
 
-	books, err := h.svc.ListBooks(c.Request.Context(), filters, sorts, fields)
+	books, info, err := h.svc.ListBooks(c.Request.Context(), filters, sorts, fields, c.Query("q"), c.Query("expand"), page)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
+	writePageHeaders(c, info)
 
 	// Convert each Book to BookResponse
 	resp := make([]response.BookResponse, len(books))
@@ -51,7 +69,33 @@ func (h *BookHandler) ListBooks(c *gin.Context) {
 		resp[i] = b.ConvertToResponse()
 	}
 
-	c.JSON(http.StatusOK, resp)
+	writeList(c, resp, info.Fields, "authors", "publisher")
+}
+
+// AggregateBooks godoc
+// @Summary Aggregate books
+// @Description Count the books matching the filters of the list, or aggregate their columns, by group. Each row holds the groups and aggregates under their names, e.g. {"author_id": 3, "count": 12}. Books can be grouped by any column, by author_id, genre_id and tag, where a book counts once in each of its groups, and by day, week, month or year of published_at. Numeric columns except ids can be summed, all but booleans have a min and max.
+// @Tags Books
+// @Produce json
+// @Param filter query []string false "Filter conditions"
+// @Param q query string false "RSQL filter expression joined to the filter conditions"
+// @Param group_by query string false "Comma separated groups, e.g. author_id,year(published_at)"
+// @Param agg query string false "Comma separated aggregates among count, min(field), max(field) and sum(field), count by default"
+// @Success 200 {array} object
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/aggregate [get]
+func (h *BookHandler) AggregateBooks(c *gin.Context) {
+	rows, err := h.svc.AggregateBooks(c.Request.Context(), c.QueryArray("filter"), c.Query("q"), c.Query("group_by"), c.Query("agg"))
+	if err != nil {
+		if errors.Is(err, query.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, rows)
 }
 
 // GetBook godoc
@@ -61,8 +105,9 @@ func (h *BookHandler) ListBooks(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Book ID"
+// @Param expand query string false "Relations to embed, comma separated: author, publisher"
 // @Success 200 {object} response.BookResponse
-// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID or expand"
 // @Failure 404 {object} response.ErrorResponse "Not Found"
 // @Failure 500 {object} response.ErrorResponse
 // @Router /books/{id} [get]
@@ -85,6 +130,49 @@ func (h *BookHandler) GetBook(c *gin.Context) {
 		return
 	}
 
+	books := []model.Book{*book}
+	err = h.svc.ExpandBooks(c.Request.Context(), books, c.Query("expand"))
+	if err != nil {
+		if errors.Is(err, query.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := books[0].ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetBookByISBN godoc
+// @Summary Get a book by ISBN
+// @Description Retrieve a single book using its ISBN-10 or ISBN-13, with or without hyphens
+// @Tags Books
+// @Accept json
+// @Produce json
+// @Param isbn path string true "ISBN-10 or ISBN-13"
+// @Success 200 {object} response.BookResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ISBN"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/isbn/{isbn} [get]
+func (h *BookHandler) GetBookByISBN(c *gin.Context) {
+	book, err := h.svc.GetBookByISBN(c.Request.Context(), c.Param("isbn"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidISBN) {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if book == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "not found"})
+		return
+	}
+
 	resp := book.ConvertToResponse()
 	c.JSON(http.StatusOK, resp)
 }
@@ -97,7 +185,8 @@ func (h *BookHandler) GetBook(c *gin.Context) {
 // @Produce json
 // @Param book body request.CreateBookRequest true "Book to create"
 // @Success 201 {object} response.BookResponse
-// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 400 {object} response.ErrorResponse "Invalid input, ISBN, contributor or publisher"
+// @Failure 409 {object} response.ErrorResponse "ISBN already used by another book"
 // @Failure 500 {object} response.ErrorResponse
 // @Router /books [post]
 func (h *BookHandler) CreateBook(c *gin.Context) {
@@ -115,8 +204,27 @@ func (h *BookHandler) CreateBook(c *gin.Context) {
 	}
 	timestamp := tm.Unix()
 
-	book, err := h.svc.CreateBook(c.Request.Context(), req.Title, req.AuthorID, timestamp)
+	book, err := h.svc.CreateBook(c.Request.Context(), model.Book{
+		Title:        req.Title,
+		ISBN13:       req.ISBN,
+		Category:     req.Category,
+		PublishedAt:  timestamp,
+		PublisherID:  req.PublisherID,
+		Edition:      req.Edition,
+		PageCount:    req.PageCount,
+		Language:     req.Language,
+		Format:       req.Format,
+		Contributors: contributors(req.Contributors),
+	})
 	if err != nil {
+		if isInvalidBookError(err) {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrDuplicateISBN) {
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -134,8 +242,9 @@ func (h *BookHandler) CreateBook(c *gin.Context) {
 // @Param id path int true "Book ID"
 // @Param book body request.UpdateBookRequest true "Book data to update"
 // @Success 200 {object} response.BookResponse
-// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 400 {object} response.ErrorResponse "Invalid input, ISBN, contributor or publisher"
 // @Failure 404 {object} response.ErrorResponse "Book not found"
+// @Failure 409 {object} response.ErrorResponse "ISBN already used by another book"
 // @Failure 500 {object} response.ErrorResponse
 // @Router /books/{id} [put]
 func (h *BookHandler) UpdateBook(c *gin.Context) {
@@ -160,12 +269,32 @@ func (h *BookHandler) UpdateBook(c *gin.Context) {
 	}
 	timestamp := tm.Unix()
 
-	book, err := h.svc.UpdateBook(c.Request.Context(), id, req.Title, req.AuthorID, timestamp)
+	book, err := h.svc.UpdateBook(c.Request.Context(), model.Book{
+		ID:           id,
+		Title:        req.Title,
+		ISBN13:       req.ISBN,
+		Category:     req.Category,
+		PublishedAt:  timestamp,
+		PublisherID:  req.PublisherID,
+		Edition:      req.Edition,
+		PageCount:    req.PageCount,
+		Language:     req.Language,
+		Format:       req.Format,
+		Contributors: contributors(req.Contributors),
+	})
 	if err != nil {
 		if err.Error() == "book not found" {
 			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
 			return
 		}
+		if isInvalidBookError(err) {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrDuplicateISBN) {
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -206,3 +335,23 @@ func (h *BookHandler) DeleteBook(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// contributors converts the contributors of a book request, keeping their order.
+func contributors(reqs []request.BookContributorRequest) []model.BookContributor {
+	result := make([]model.BookContributor, len(reqs))
+	for i, r := range reqs {
+		result[i] = model.BookContributor{AuthorID: r.AuthorID, Role: r.Role}
+	}
+	return result
+}
+
+// isInvalidBookError reports whether err rejects the data of a book request.
+func isInvalidBookError(err error) bool {
+	return errors.Is(err, service.ErrAuthorNotFound) ||
+		errors.Is(err, service.ErrInvalidContributorRole) ||
+		errors.Is(err, service.ErrDuplicateContributor) ||
+		errors.Is(err, service.ErrInvalidISBN) ||
+		errors.Is(err, service.ErrPublisherNotFound) ||
+		errors.Is(err, service.ErrInvalidBookFormat) ||
+		errors.Is(err, service.ErrInvalidLanguage)
+}
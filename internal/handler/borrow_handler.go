@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/domain/model"
 	"borrow_book/internal/domain/request"
 	"borrow_book/internal/domain/response"
+	"borrow_book/internal/infra/database/query"
 	"borrow_book/internal/service"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -13,12 +17,13 @@ import (
 
 // BorrowHandler handles borrow-related HTTP requests.
 type BorrowHandler struct {
-	svc service.BorrowService
+	svc    service.BorrowService
+	paging config.PagingConfig
 }
 
 // NewBorrowHandler creates a new BorrowHandler.
-func NewBorrowHandler(svc service.BorrowService) *BorrowHandler {
-	return &BorrowHandler{svc: svc}
+func NewBorrowHandler(svc service.BorrowService, cfg *config.Config) *BorrowHandler {
+	return &BorrowHandler{svc: svc, paging: cfg.Paging}
 }
 
 // ListBorrows godoc
@@ -28,9 +33,19 @@ func NewBorrowHandler(svc service.BorrowService) *BorrowHandler {
 // @Accept json
 // @Produce json
 // @Param filter query []string false "Filter conditions"
+// @Param q query string false "RSQL filter expression joined to the filter conditions, e.g. (member_id==3,member_id==5);returned_at=isnull=true"
 // @Param sort query []string false "Sort conditions"
-// @Param fields query string false "Fields to select"
+// @Param fields query string false "Comma separated fields to select, the only ones returned along with expanded relations"
+// @Param expand query string false "Relations to embed, comma separated: book, book.author, book.publisher, member"
+// @Param status query string false "Borrow status" Enums(open, returned, overdue)
+// @Param limit query int false "Rows per page, up to the configured maximum"
+// @Param offset query int false "Rows to skip, cannot be combined with cursor"
+// @Param cursor query string false "X-Next-Cursor of the previous page"
+// @Param total query bool false "Return the number of matching rows in X-Total-Count"
 // @Success 200 {array} response.BorrowResponse
+// @Header 200 {string} Link "Link to the next page"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last page"
+// @Header 200 {integer} X-Total-Count "Number of matching rows, when requested"
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 500 {object} response.ErrorResponse
 // @Router /borrows [get]
@@ -38,19 +53,54 @@ func (h *BorrowHandler) ListBorrows(c *gin.Context) {
 	filters := c.QueryArray("filter")
 	sorts := c.QueryArray("sort")
 	fields := c.Query("fields")
+	status := c.Query("status")
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
 
-	borrows, err := h.svc.ListBorrowLists(c.Request.Context(), filters, sorts, fields)
+	borrows, info, err := h.svc.ListBorrowLists(c.Request.Context(), filters, sorts, fields, status, c.Query("q"), c.Query("expand"), page)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
 		return
 	}
+	writePageHeaders(c, info)
 
 	resp := make([]response.BorrowResponse, len(borrows))
 	for i, b := range borrows {
 		resp[i] = b.ConvertToResponse()
 	}
 
-	c.JSON(http.StatusOK, resp)
+	writeList(c, resp, info.Fields, "book", "member")
+}
+
+// AggregateBorrows godoc
+// @Summary Aggregate borrows
+// @Description Count the borrows matching the filters of the list, or aggregate their columns, by group. Each row holds the groups and aggregates under their names, e.g. {"month(borrowed_at)": "2024-05", "count": 40}. Borrows can be grouped by any column and by day, week, month or year of borrowed_at, due_at and returned_at, in UTC. Weeks are ISO weeks, e.g. 2024-W19.
+// @Tags Borrows
+// @Produce json
+// @Param filter query []string false "Filter conditions"
+// @Param q query string false "RSQL filter expression joined to the filter conditions"
+// @Param status query string false "Borrow status" Enums(open, returned, overdue)
+// @Param group_by query string false "Comma separated groups, e.g. member_id,month(borrowed_at)"
+// @Param agg query string false "Comma separated aggregates among count, min(field), max(field) and sum(field), count by default"
+// @Success 200 {array} object
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /borrows/aggregate [get]
+func (h *BorrowHandler) AggregateBorrows(c *gin.Context) {
+	rows, err := h.svc.AggregateBorrows(c.Request.Context(), c.QueryArray("filter"), c.Query("status"), c.Query("q"), c.Query("group_by"), c.Query("agg"))
+	if err != nil {
+		switch {
+		case errors.Is(err, query.ErrInvalidQuery), errors.Is(err, service.ErrInvalidBorrowStatus):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, rows)
 }
 
 // GetBorrow godoc
@@ -60,9 +110,11 @@ func (h *BorrowHandler) ListBorrows(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Borrow ID"
+// @Param expand query string false "Relations to embed, comma separated: book, book.author, book.publisher, member"
 // @Success 200 {object} response.BorrowResponse
 // @Failure 400 {object} response.ErrorResponse
 // @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
 // @Router /borrows/{id} [get]
 func (h *BorrowHandler) GetBorrow(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -82,19 +134,32 @@ func (h *BorrowHandler) GetBorrow(c *gin.Context) {
 		return
 	}
 
-	resp := borrow.ConvertToResponse()
+	borrows := []model.Borrow{*borrow}
+	err = h.svc.ExpandBorrows(c.Request.Context(), borrows, c.Query("expand"))
+	if err != nil {
+		if errors.Is(err, query.ErrInvalidQuery) {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := borrows[0].ConvertToResponse()
 	c.JSON(http.StatusOK, resp)
 }
 
 // CreateBorrow godoc
 // @Summary Create a new borrow
-// @Description Add a new borrow to the system
+// @Description Lend a copy of a book to a member. With a branch_id only copies at that branch are lent.
 // @Tags Borrows
 // @Accept json
 // @Produce json
 // @Param borrow body request.CreateBorrowRequest true "Borrow to create"
 // @Success 200 {object} response.BorrowResponse
 // @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Book, copy, branch or member not found"
+// @Failure 409 {object} response.PolicyViolationResponse "No copy available, member not allowed to borrow, loan limit reached or outstanding fines over the threshold"
 // @Failure 500 {object} response.ErrorResponse
 // @Router /borrows [post]
 func (h *BorrowHandler) CreateBorrow(c *gin.Context) {
@@ -111,9 +176,24 @@ func (h *BorrowHandler) CreateBorrow(c *gin.Context) {
 	}
 	timestamp := tm.Unix()
 
-	borrow, err := h.svc.CreateBorrow(c.Request.Context(), req.BookID, req.UserName, timestamp)
+	borrow, err := h.svc.CreateBorrow(c.Request.Context(), req.BookID, req.CopyID, req.BranchID, req.MemberID, timestamp)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		if writePolicyViolation(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, service.ErrBookNotFound), errors.Is(err, service.ErrCopyNotFound),
+			errors.Is(err, service.ErrMemberNotFound), errors.Is(err, service.ErrBranchNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrCopyBookMismatch), errors.Is(err, service.ErrCopyBranchMismatch):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrNoCopyAvailable), errors.Is(err, service.ErrCopyNotAvailable),
+			errors.Is(err, service.ErrFinesOverThreshold), errors.Is(err, service.ErrMemberSuspended),
+			errors.Is(err, service.ErrMembershipExpired), errors.Is(err, service.ErrReservationNotActive):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
 		return
 	}
 
@@ -123,7 +203,7 @@ func (h *BorrowHandler) CreateBorrow(c *gin.Context) {
 
 // UpdateBorrow godoc
 // @Summary Update an existing borrow
-// @Description Modify the details of an existing borrow using its ID
+// @Description Modify the details of an existing borrow using its ID. Changing the borrow date moves the due date with it.
 // @Tags Borrows
 // @Accept json
 // @Produce json
@@ -154,9 +234,16 @@ func (h *BorrowHandler) UpdateBorrow(c *gin.Context) {
 	}
 	timestamp := tm.Unix()
 
-	borrow, err := h.svc.UpdateBorrow(c.Request.Context(), id, req.BookID, req.UserName, timestamp)
+	borrow, err := h.svc.UpdateBorrow(c.Request.Context(), id, req.BookID, req.MemberID, timestamp)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		switch {
+		case errors.Is(err, service.ErrBorrowNotFound), errors.Is(err, service.ErrMemberNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrBorrowBookChanged):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
 		return
 	}
 
@@ -195,3 +282,201 @@ func (h *BorrowHandler) DeleteBorrow(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// ReturnBorrow godoc
+// @Summary Return a borrowed book
+// @Description Close an open borrow by recording its return date. Late returns are charged a fine. Copies may be returned to any branch and stay there until transferred.
+// @Tags Borrows
+// @Accept json
+// @Produce json
+// @Param id path int true "Borrow ID"
+// @Param return body request.ReturnBorrowRequest false "Branch the copy is returned to"
+// @Success 200 {object} response.BorrowResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Borrow or branch not found"
+// @Failure 409 {object} response.ErrorResponse "Borrow already returned"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /borrows/{id}/return [post]
+func (h *BorrowHandler) ReturnBorrow(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.ReturnBorrowRequest
+	if err := bindOptionalJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	borrow, err := h.svc.ReturnBorrow(c.Request.Context(), id, req.BranchID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBorrowNotFound), errors.Is(err, service.ErrBranchNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrBorrowAlreadyReturned):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	resp := borrow.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// RenewBorrow godoc
+// @Summary Renew a borrow
+// @Description Extend the due date of an open borrow by a loan period. Renewals are limited and refused while other patrons wait for the book.
+// @Tags Borrows
+// @Accept json
+// @Produce json
+// @Param id path int true "Borrow ID"
+// @Success 200 {object} response.BorrowResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 409 {object} response.PolicyViolationResponse "Borrow returned, renewal limit reached or book reserved"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /borrows/{id}/renew [post]
+func (h *BorrowHandler) RenewBorrow(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	borrow, err := h.svc.RenewBorrow(c.Request.Context(), id)
+	if err != nil {
+		if writePolicyViolation(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, service.ErrBorrowNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrBorrowAlreadyReturned), errors.Is(err, service.ErrBookReserved):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	resp := borrow.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListRenewals godoc
+// @Summary List renewal attempts of a borrow
+// @Description Get the audit log of successful and refused renewals of a borrow
+// @Tags Borrows
+// @Accept json
+// @Produce json
+// @Param id path int true "Borrow ID"
+// @Success 200 {array} response.BorrowRenewalResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /borrows/{id}/renewals [get]
+func (h *BorrowHandler) ListRenewals(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	renewals, err := h.svc.ListRenewals(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrBorrowNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.BorrowRenewalResponse, len(renewals))
+	for i, r := range renewals {
+		resp[i] = r.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListMemberBorrows godoc
+// @Summary List the borrow history of a member
+// @Description Get the loans of a member with the title and author of their books, current loans first, then most recent first
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path int true "Member ID"
+// @Param loans query string false "Only current or past loans" Enums(current, past)
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Loans per page, up to the configured maximum page size"
+// @Success 200 {object} response.BorrowHistoryPageResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Member not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /members/{id}/borrows [get]
+func (h *BorrowHandler) ListMemberBorrows(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	page, pageSize, err := parsePage(c, h.paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	entries, total, err := h.svc.ListMemberBorrows(c.Request.Context(), id, c.Query("loans"), page, pageSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMemberNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrInvalidBorrowStatus):
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	resp := response.BorrowHistoryPageResponse{
+		Items:    make([]response.BorrowHistoryResponse, len(entries)),
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}
+	for i, e := range entries {
+		resp.Items[i] = e.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// writePolicyViolation answers with the loan policy rule that refused the request.
+// It reports false when err is not a policy violation.
+func writePolicyViolation(c *gin.Context, err error) bool {
+	var violation *service.PolicyViolationError
+	if !errors.As(err, &violation) {
+		return false
+	}
+	c.JSON(http.StatusConflict, response.PolicyViolationResponse{
+		Error:          violation.Error(),
+		Rule:           violation.Rule,
+		PolicyID:       violation.PolicyID,
+		MemberCategory: violation.MemberCategory,
+		BookCategory:   violation.BookCategory,
+		Limit:          violation.Limit,
+		Current:        violation.Current,
+	})
+	return true
+}
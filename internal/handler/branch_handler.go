@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/domain/request"
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BranchHandler handles branch-related HTTP requests.
+type BranchHandler struct {
+	svc service.BranchService
+}
+
+// NewBranchHandler creates a new BranchHandler.
+func NewBranchHandler(svc service.BranchService) *BranchHandler {
+	return &BranchHandler{svc: svc}
+}
+
+// ListBranches godoc
+// @Summary List branches
+// @Description Get a list of library branches with optional filters, sorts, and selected fields
+// @Tags Branches
+// @Accept json
+// @Produce json
+// @Param filter query []string false "Filter conditions"
+// @Param sort query []string false "Sort conditions"
+// @Param fields query string false "Fields to select"
+// @Success 200 {array} response.BranchResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /branches [get]
+func (h *BranchHandler) ListBranches(c *gin.Context) {
+	filters := c.QueryArray("filter")
+	sorts := c.QueryArray("sort")
+	fields := c.Query("fields")
+
+	branches, err := h.svc.ListBranches(c.Request.Context(), filters, sorts, fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.BranchResponse, len(branches))
+	for i, b := range branches {
+		resp[i] = b.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetBranch godoc
+// @Summary Get a branch by ID
+// @Description Retrieve a single library branch using its unique ID
+// @Tags Branches
+// @Accept json
+// @Produce json
+// @Param id path int true "Branch ID"
+// @Success 200 {object} response.BranchResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /branches/{id} [get]
+func (h *BranchHandler) GetBranch(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	branch, err := h.svc.GetBranch(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if branch == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "not found"})
+		return
+	}
+
+	resp := branch.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateBranch godoc
+// @Summary Create a new branch
+// @Description Add a new library branch. Codes are stored upper case.
+// @Tags Branches
+// @Accept json
+// @Produce json
+// @Param branch body request.CreateBranchRequest true "Branch to create"
+// @Success 201 {object} response.BranchResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 409 {object} response.ErrorResponse "Branch code already in use"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /branches [post]
+func (h *BranchHandler) CreateBranch(c *gin.Context) {
+	var req request.CreateBranchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	branch, err := h.svc.CreateBranch(c.Request.Context(), model.Branch{
+		Code:    req.Code,
+		Name:    req.Name,
+		Address: req.Address,
+	})
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := branch.ConvertToResponse()
+	c.JSON(http.StatusCreated, resp)
+}
+
+// UpdateBranch godoc
+// @Summary Update an existing branch
+// @Description Modify the code, name or address of a library branch
+// @Tags Branches
+// @Accept json
+// @Produce json
+// @Param id path int true "Branch ID"
+// @Param branch body request.UpdateBranchRequest true "Branch data to update"
+// @Success 200 {object} response.BranchResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 404 {object} response.ErrorResponse "Branch not found"
+// @Failure 409 {object} response.ErrorResponse "Branch code already in use"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /branches/{id} [put]
+func (h *BranchHandler) UpdateBranch(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.UpdateBranchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	branch, err := h.svc.UpdateBranch(c.Request.Context(), model.Branch{
+		ID:      id,
+		Code:    req.Code,
+		Name:    req.Name,
+		Address: req.Address,
+	})
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := branch.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteBranch godoc
+// @Summary Delete a branch
+// @Description Remove a branch that holds no copies and has no loan or transfer history
+// @Tags Branches
+// @Accept json
+// @Produce json
+// @Param id path int true "Branch ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Branch not found"
+// @Failure 409 {object} response.ErrorResponse "Branch still in use"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /branches/{id} [delete]
+func (h *BranchHandler) DeleteBranch(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	err = h.svc.DeleteBranch(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *BranchHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrBranchNotFound):
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrDuplicateBranchCode), errors.Is(err, service.ErrBranchInUse):
+		c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+}
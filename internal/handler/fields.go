@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/response"
+	"borrow_book/pkg/fieldset"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeList writes the items of a list response, narrowed down to the fields
+// they were selected with, when the request selected some, and to the
+// relations, which are left out of the items unless expanded.
+func writeList[T any](c *gin.Context, items []T, fields []string, relations ...string) {
+	if len(fields) == 0 {
+		c.JSON(http.StatusOK, items)
+		return
+	}
+	fields = append(fields[:len(fields):len(fields)], relations...)
+
+	resp := make([]interface{}, len(items))
+	for i, item := range items {
+		selected, err := fieldset.Select(item, fields...)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		resp[i] = selected
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/request"
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FineHandler handles fines ledger HTTP requests.
+type FineHandler struct {
+	svc service.FineService
+}
+
+// NewFineHandler creates a new FineHandler.
+func NewFineHandler(svc service.FineService) *FineHandler {
+	return &FineHandler{svc: svc}
+}
+
+// ListFines godoc
+// @Summary List fines ledger entries
+// @Description Get fines, payments and waivers with optional filters, sorts, and selected fields
+// @Tags Fines
+// @Accept json
+// @Produce json
+// @Param filter query []string false "Filter conditions"
+// @Param sort query []string false "Sort conditions"
+// @Param fields query string false "Fields to select"
+// @Success 200 {array} response.FineEntryResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /fines [get]
+func (h *FineHandler) ListFines(c *gin.Context) {
+	filters := c.QueryArray("filter")
+	sorts := c.QueryArray("sort")
+	fields := c.Query("fields")
+
+	entries, err := h.svc.ListEntries(c.Request.Context(), filters, sorts, fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.FineEntryResponse, len(entries))
+	for i, e := range entries {
+		resp[i] = e.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListOutstandingBalances godoc
+// @Summary List outstanding balances
+// @Description Get every patron who still owes fines, highest balance first
+// @Tags Fines
+// @Accept json
+// @Produce json
+// @Success 200 {array} response.FineBalanceResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /fines/balances [get]
+func (h *FineHandler) ListOutstandingBalances(c *gin.Context) {
+	balances, err := h.svc.ListOutstandingBalances(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.FineBalanceResponse, len(balances))
+	for i, b := range balances {
+		resp[i] = b.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetBalance godoc
+// @Summary Get the balance of a patron
+// @Description Retrieve the outstanding fines of a single patron
+// @Tags Fines
+// @Accept json
+// @Produce json
+// @Param member_id path int true "Member ID"
+// @Success 200 {object} response.FineBalanceResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Member not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /fines/balances/{member_id} [get]
+func (h *FineHandler) GetBalance(c *gin.Context) {
+	memberID, err := strconv.Atoi(c.Param("member_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	balance, err := h.svc.GetBalance(c.Request.Context(), memberID)
+	if err != nil {
+		if errors.Is(err, service.ErrMemberNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := balance.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// RecordPayment godoc
+// @Summary Record a fine payment
+// @Description Settle part or all of the outstanding balance of a patron
+// @Tags Fines
+// @Accept json
+// @Produce json
+// @Param payment body request.FinePaymentRequest true "Payment to record"
+// @Success 201 {object} response.FineEntryResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input or amount over the balance"
+// @Failure 404 {object} response.ErrorResponse "Member not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /fines/payments [post]
+func (h *FineHandler) RecordPayment(c *gin.Context) {
+	var req request.FinePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	entry, err := h.svc.RecordPayment(c.Request.Context(), req.MemberID, req.Amount, req.Reason)
+	if err != nil {
+		h.writeSettleError(c, err)
+		return
+	}
+
+	resp := entry.ConvertToResponse()
+	c.JSON(http.StatusCreated, resp)
+}
+
+// WaiveFine godoc
+// @Summary Waive fines
+// @Description Forgive part or all of the outstanding balance of a patron. A reason is required.
+// @Tags Fines
+// @Accept json
+// @Produce json
+// @Param waiver body request.FineWaiverRequest true "Waiver to record"
+// @Success 201 {object} response.FineEntryResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input or amount over the balance"
+// @Failure 404 {object} response.ErrorResponse "Member not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /fines/waivers [post]
+func (h *FineHandler) WaiveFine(c *gin.Context) {
+	var req request.FineWaiverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	entry, err := h.svc.WaiveFine(c.Request.Context(), req.MemberID, req.Amount, req.Reason, req.BorrowID)
+	if err != nil {
+		h.writeSettleError(c, err)
+		return
+	}
+
+	resp := entry.ConvertToResponse()
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *FineHandler) writeSettleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrMemberNotFound):
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrInvalidAmount), errors.Is(err, service.ErrAmountExceedsBalance):
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+}
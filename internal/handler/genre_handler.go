@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/domain/request"
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GenreHandler handles genre-related HTTP requests.
+type GenreHandler struct {
+	svc service.GenreService
+}
+
+// NewGenreHandler creates a new GenreHandler.
+func NewGenreHandler(svc service.GenreService) *GenreHandler {
+	return &GenreHandler{svc: svc}
+}
+
+// ListGenres godoc
+// @Summary List genres
+// @Description Get a list of genres with optional filters, sorts, and selected fields. Filter on parent_id to walk the hierarchy.
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param filter query []string false "Filter conditions"
+// @Param sort query []string false "Sort conditions"
+// @Param fields query string false "Fields to select"
+// @Success 200 {array} response.GenreResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /genres [get]
+func (h *GenreHandler) ListGenres(c *gin.Context) {
+	filters := c.QueryArray("filter")
+	sorts := c.QueryArray("sort")
+	fields := c.Query("fields")
+
+	genres, err := h.svc.ListGenres(c.Request.Context(), filters, sorts, fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.GenreResponse, len(genres))
+	for i, g := range genres {
+		resp[i] = g.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetGenre godoc
+// @Summary Get a genre by ID
+// @Description Retrieve a single genre using its unique ID
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Success 200 {object} response.GenreResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /genres/{id} [get]
+func (h *GenreHandler) GetGenre(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	genre, err := h.svc.GetGenre(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if genre == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "not found"})
+		return
+	}
+
+	resp := genre.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateGenre godoc
+// @Summary Create a new genre
+// @Description Add a genre or subject, optionally under a parent genre
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param genre body request.CreateGenreRequest true "Genre to create"
+// @Success 201 {object} response.GenreResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input or parent genre not found"
+// @Failure 409 {object} response.ErrorResponse "Genre already exists under this parent"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /genres [post]
+func (h *GenreHandler) CreateGenre(c *gin.Context) {
+	var req request.CreateGenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	genre, err := h.svc.CreateGenre(c.Request.Context(), model.Genre{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := genre.ConvertToResponse()
+	c.JSON(http.StatusCreated, resp)
+}
+
+// UpdateGenre godoc
+// @Summary Update an existing genre
+// @Description Rename a genre or move it under another parent
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Param genre body request.UpdateGenreRequest true "Genre data to update"
+// @Success 200 {object} response.GenreResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input, parent genre not found or moved under itself"
+// @Failure 404 {object} response.ErrorResponse "Genre not found"
+// @Failure 409 {object} response.ErrorResponse "Genre already exists under this parent"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /genres/{id} [put]
+func (h *GenreHandler) UpdateGenre(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.UpdateGenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	genre, err := h.svc.UpdateGenre(c.Request.Context(), model.Genre{
+		ID:       id,
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := genre.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteGenre godoc
+// @Summary Delete a genre
+// @Description Remove a genre without sub-genres, its books lose the genre
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Genre not found"
+// @Failure 409 {object} response.ErrorResponse "Genre still has sub-genres"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /genres/{id} [delete]
+func (h *GenreHandler) DeleteGenre(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	err = h.svc.DeleteGenre(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddBookGenre godoc
+// @Summary Classify a book under a genre
+// @Description Assign a genre to a book. Assigning a genre twice has no effect.
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param genre_id path int true "Genre ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Book or genre not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/{id}/genres/{genre_id} [post]
+func (h *GenreHandler) AddBookGenre(c *gin.Context) {
+	bookID, genreID, ok := parseBookRelationIDs(c, "genre_id")
+	if !ok {
+		return
+	}
+
+	err := h.svc.AddBookGenre(c.Request.Context(), bookID, genreID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveBookGenre godoc
+// @Summary Remove a genre from a book
+// @Description Unassign a genre from a book
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param genre_id path int true "Genre ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Book not found or genre not assigned"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/{id}/genres/{genre_id} [delete]
+func (h *GenreHandler) RemoveBookGenre(c *gin.Context) {
+	bookID, genreID, ok := parseBookRelationIDs(c, "genre_id")
+	if !ok {
+		return
+	}
+
+	err := h.svc.RemoveBookGenre(c.Request.Context(), bookID, genreID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *GenreHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrGenreNotFound), errors.Is(err, service.ErrBookNotFound):
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrParentGenreNotFound), errors.Is(err, service.ErrGenreCycle):
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrDuplicateGenre), errors.Is(err, service.ErrGenreHasChildren):
+		c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// parseBookRelationIDs reads the book id and the id of the related record
+// from the path. It answers with 400 and reports false when one is invalid.
+func parseBookRelationIDs(c *gin.Context, param string) (bookID, relatedID int, ok bool) {
+	bookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return 0, 0, false
+	}
+	relatedID, err = strconv.Atoi(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid " + param})
+		return 0, 0, false
+	}
+	return bookID, relatedID, true
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/domain/request"
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoanPolicyHandler handles loan policy HTTP requests.
+type LoanPolicyHandler struct {
+	svc service.LoanPolicyService
+}
+
+// NewLoanPolicyHandler creates a new LoanPolicyHandler.
+func NewLoanPolicyHandler(svc service.LoanPolicyService) *LoanPolicyHandler {
+	return &LoanPolicyHandler{svc: svc}
+}
+
+// ListLoanPolicies godoc
+// @Summary List loan policies
+// @Description Get a list of loan policies with optional filters, sorts, and selected fields
+// @Tags LoanPolicies
+// @Accept json
+// @Produce json
+// @Param filter query []string false "Filter conditions"
+// @Param sort query []string false "Sort conditions"
+// @Param fields query string false "Fields to select"
+// @Success 200 {array} response.LoanPolicyResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /loan-policies [get]
+func (h *LoanPolicyHandler) ListLoanPolicies(c *gin.Context) {
+	filters := c.QueryArray("filter")
+	sorts := c.QueryArray("sort")
+	fields := c.Query("fields")
+
+	policies, err := h.svc.ListPolicies(c.Request.Context(), filters, sorts, fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.LoanPolicyResponse, len(policies))
+	for i, p := range policies {
+		resp[i] = p.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetLoanPolicy godoc
+// @Summary Get a loan policy by ID
+// @Description Retrieve a single loan policy using its unique ID
+// @Tags LoanPolicies
+// @Accept json
+// @Produce json
+// @Param id path int true "Loan policy ID"
+// @Success 200 {object} response.LoanPolicyResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /loan-policies/{id} [get]
+func (h *LoanPolicyHandler) GetLoanPolicy(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	policy, err := h.svc.GetPolicy(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if policy == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "not found"})
+		return
+	}
+
+	resp := policy.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateLoanPolicy godoc
+// @Summary Create a new loan policy
+// @Description Set the borrowing rules of a member category, optionally restricted to a book category
+// @Tags LoanPolicies
+// @Accept json
+// @Produce json
+// @Param policy body request.CreateLoanPolicyRequest true "Loan policy to create"
+// @Success 201 {object} response.LoanPolicyResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 409 {object} response.ErrorResponse "Policy already exists for these categories"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /loan-policies [post]
+func (h *LoanPolicyHandler) CreateLoanPolicy(c *gin.Context) {
+	var req request.CreateLoanPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	policy, err := h.svc.CreatePolicy(c.Request.Context(), model.LoanPolicy{
+		MemberCategory: req.MemberCategory,
+		BookCategory:   req.BookCategory,
+		MaxLoans:       req.MaxLoans,
+		LoanPeriodDays: req.LoanPeriodDays,
+		MaxRenewals:    req.MaxRenewals,
+	})
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := policy.ConvertToResponse()
+	c.JSON(http.StatusCreated, resp)
+}
+
+// UpdateLoanPolicy godoc
+// @Summary Update an existing loan policy
+// @Description Modify the rules of an existing loan policy using its ID
+// @Tags LoanPolicies
+// @Accept json
+// @Produce json
+// @Param id path int true "Loan policy ID"
+// @Param policy body request.UpdateLoanPolicyRequest true "Loan policy data to update"
+// @Success 200 {object} response.LoanPolicyResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 404 {object} response.ErrorResponse "Loan policy not found"
+// @Failure 409 {object} response.ErrorResponse "Policy already exists for these categories"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /loan-policies/{id} [put]
+func (h *LoanPolicyHandler) UpdateLoanPolicy(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.UpdateLoanPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	policy, err := h.svc.UpdatePolicy(c.Request.Context(), model.LoanPolicy{
+		ID:             id,
+		MemberCategory: req.MemberCategory,
+		BookCategory:   req.BookCategory,
+		MaxLoans:       req.MaxLoans,
+		LoanPeriodDays: req.LoanPeriodDays,
+		MaxRenewals:    req.MaxRenewals,
+	})
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := policy.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteLoanPolicy godoc
+// @Summary Delete a loan policy
+// @Description Remove a loan policy, its members fall back to the more general policy or the library defaults
+// @Tags LoanPolicies
+// @Accept json
+// @Produce json
+// @Param id path int true "Loan policy ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Loan policy not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /loan-policies/{id} [delete]
+func (h *LoanPolicyHandler) DeleteLoanPolicy(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	err = h.svc.DeletePolicy(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *LoanPolicyHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrLoanPolicyNotFound):
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrInvalidLoanPolicy):
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrDuplicateLoanPolicy):
+		c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+}
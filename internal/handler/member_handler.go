@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/domain/request"
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MemberHandler handles member-related HTTP requests.
+type MemberHandler struct {
+	svc service.MemberService
+}
+
+// NewMemberHandler creates a new MemberHandler.
+func NewMemberHandler(svc service.MemberService) *MemberHandler {
+	return &MemberHandler{svc: svc}
+}
+
+// ListMembers godoc
+// @Summary List members
+// @Description Get a list of members with optional filters, sorts, and selected fields
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param filter query []string false "Filter conditions"
+// @Param sort query []string false "Sort conditions"
+// @Param fields query string false "Fields to select"
+// @Success 200 {array} response.MemberResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /members [get]
+func (h *MemberHandler) ListMembers(c *gin.Context) {
+	filters := c.QueryArray("filter")
+	sorts := c.QueryArray("sort")
+	fields := c.Query("fields")
+
+	members, err := h.svc.ListMembers(c.Request.Context(), filters, sorts, fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.MemberResponse, len(members))
+	for i, m := range members {
+		resp[i] = m.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetMember godoc
+// @Summary Get a member by ID
+// @Description Retrieve a single member using their unique ID
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path int true "Member ID"
+// @Success 200 {object} response.MemberResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /members/{id} [get]
+func (h *MemberHandler) GetMember(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	member, err := h.svc.GetMember(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if member == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "not found"})
+		return
+	}
+
+	resp := member.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateMember godoc
+// @Summary Create a new member
+// @Description Register a new patron of the library
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param member body request.CreateMemberRequest true "Member to create"
+// @Success 201 {object} response.MemberResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 409 {object} response.ErrorResponse "Card number already in use"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /members [post]
+func (h *MemberHandler) CreateMember(c *gin.Context) {
+	var req request.CreateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Parse the date string to UNIX timestamp
+	tm, err := time.Parse("2006-01-02", req.ExpiresAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid expires_at format"})
+		return
+	}
+
+	member, err := h.svc.CreateMember(c.Request.Context(), model.Member{
+		CardNumber: req.CardNumber,
+		Name:       req.Name,
+		Email:      req.Email,
+		Phone:      req.Phone,
+		Category:   req.Category,
+		ExpiresAt:  tm.Unix(),
+	})
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := member.ConvertToResponse()
+	c.JSON(http.StatusCreated, resp)
+}
+
+// UpdateMember godoc
+// @Summary Update an existing member
+// @Description Modify the details of an existing member using their ID
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path int true "Member ID"
+// @Param member body request.UpdateMemberRequest true "Member data to update"
+// @Success 200 {object} response.MemberResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 404 {object} response.ErrorResponse "Member not found"
+// @Failure 409 {object} response.ErrorResponse "Card number already in use"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /members/{id} [put]
+func (h *MemberHandler) UpdateMember(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.UpdateMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// Parse the date string to UNIX timestamp
+	tm, err := time.Parse("2006-01-02", req.ExpiresAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid expires_at format"})
+		return
+	}
+
+	member, err := h.svc.UpdateMember(c.Request.Context(), model.Member{
+		ID:         id,
+		CardNumber: req.CardNumber,
+		Name:       req.Name,
+		Email:      req.Email,
+		Phone:      req.Phone,
+		Category:   req.Category,
+		Status:     req.Status,
+		ExpiresAt:  tm.Unix(),
+	})
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := member.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteMember godoc
+// @Summary Delete a member
+// @Description Remove a member without borrow or fine history from the system
+// @Tags Members
+// @Accept json
+// @Produce json
+// @Param id path int true "Member ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Member not found"
+// @Failure 409 {object} response.ErrorResponse "Member has history"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /members/{id} [delete]
+func (h *MemberHandler) DeleteMember(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	err = h.svc.DeleteMember(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *MemberHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrMemberNotFound):
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrInvalidMemberStatus):
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrDuplicateCardNumber), errors.Is(err, service.ErrMemberHasHistory):
+		c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+}
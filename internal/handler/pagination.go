@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/infra/database/query"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parsePage reads the page and page_size query parameters of the borrow history
+// of a member, defaulting to the first page. It pages by number rather than by
+// cursor because the history is ordered on an expression, current loans first,
+// that query.BuildSelectQuery cannot express; its bounds are those of every list.
+func parsePage(c *gin.Context, paging config.PagingConfig) (page, pageSize int, err error) {
+	page, pageSize = 1, paging.DefaultPageSize
+	if raw := c.Query("page"); raw != "" {
+		page, err = strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("invalid page: %s", raw)
+		}
+	}
+	if raw := c.Query("page_size"); raw != "" {
+		pageSize, err = strconv.Atoi(raw)
+		if err != nil || pageSize < 1 || pageSize > paging.MaxPageSize {
+			return 0, 0, fmt.Errorf("invalid page_size: %s, expected 1 to %d", raw, paging.MaxPageSize)
+		}
+	}
+	return page, pageSize, nil
+}
+
+// bindPageRequest reads the limit, offset, cursor and total query parameters of a list request.
+func bindPageRequest(c *gin.Context) (query.PageRequest, error) {
+	var req query.PageRequest
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			return req, fmt.Errorf("invalid limit")
+		}
+		req.Limit = limit
+	}
+	if raw := c.Query("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil {
+			return req, fmt.Errorf("invalid offset")
+		}
+		req.Offset = offset
+	}
+	if raw := c.Query("total"); raw != "" {
+		total, err := strconv.ParseBool(raw)
+		if err != nil {
+			return req, fmt.Errorf("invalid total")
+		}
+		req.WithTotal = total
+	}
+	req.Cursor = c.Query("cursor")
+	return req, nil
+}
+
+// writePageHeaders describes the returned page in the X-Total-Count and X-Next-Cursor
+// headers, and links to the next page with a Link header. The next link keeps
+// paging by offset when the request did and uses the cursor otherwise.
+func writePageHeaders(c *gin.Context, info query.PageInfo) {
+	if info.Total != nil {
+		c.Header("X-Total-Count", strconv.Itoa(*info.Total))
+	}
+	if !info.HasMore() {
+		return
+	}
+	c.Header("X-Next-Cursor", info.NextCursor)
+
+	next := *c.Request.URL
+	params := next.Query()
+	params.Set("limit", strconv.Itoa(info.Limit))
+	if params.Get("offset") != "" {
+		params.Set("offset", strconv.Itoa(info.Offset+info.Limit))
+	} else {
+		params.Set("cursor", info.NextCursor)
+	}
+	next.RawQuery = params.Encode()
+	c.Header("Link", fmt.Sprintf(`<%s>; rel="next"`, next.RequestURI()))
+}
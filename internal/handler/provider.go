@@ -5,5 +5,17 @@ import "github.com/google/wire"
 var ProviderSetHandler = wire.NewSet(
 	NewBookHandler,
 	NewAuthorHandler,
+	NewPublisherHandler,
 	NewBorrowHandler,
+	NewBookCopyHandler,
+	NewReservationHandler,
+	NewFineHandler,
+	NewMemberHandler,
+	NewLoanPolicyHandler,
+	NewGenreHandler,
+	NewTagHandler,
+	NewBranchHandler,
+	NewTransferHandler,
+	NewBookCoverHandler,
+	NewSearchHandler,
 )
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/request"
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublisherHandler handles publisher-related HTTP requests.
+type PublisherHandler struct {
+	svc service.PublisherService
+}
+
+// NewPublisherHandler creates a new PublisherHandler.
+func NewPublisherHandler(svc service.PublisherService) *PublisherHandler {
+	return &PublisherHandler{svc: svc}
+}
+
+// ListPublishers godoc
+// @Summary List publishers
+// @Description Get a list of publishers with optional filters, sorts, and selected fields
+// @Tags Publishers
+// @Accept json
+// @Produce json
+// @Param filter query []string false "Filter conditions"
+// @Param sort query []string false "Sort conditions"
+// @Param fields query string false "Fields to select"
+// @Success 200 {array} response.PublisherResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /publishers [get]
+func (h *PublisherHandler) ListPublishers(c *gin.Context) {
+	filters := c.QueryArray("filter")
+	sorts := c.QueryArray("sort")
+	fields := c.Query("fields")
+
+	publishers, err := h.svc.ListPublishers(c.Request.Context(), filters, sorts, fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.PublisherResponse, len(publishers))
+	for i, p := range publishers {
+		resp[i] = p.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetPublisher godoc
+// @Summary Get a publisher by ID
+// @Description Retrieve a single publisher using its unique ID
+// @Tags Publishers
+// @Accept json
+// @Produce json
+// @Param id path int true "Publisher ID"
+// @Success 200 {object} response.PublisherResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /publishers/{id} [get]
+func (h *PublisherHandler) GetPublisher(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	publisher, err := h.svc.GetPublisher(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if publisher == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "not found"})
+		return
+	}
+
+	resp := publisher.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreatePublisher godoc
+// @Summary Create a new publisher
+// @Description Add a new publisher to the system
+// @Tags Publishers
+// @Accept json
+// @Produce json
+// @Param publisher body request.CreatePublisherRequest true "Publisher to create"
+// @Success 201 {object} response.PublisherResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 409 {object} response.ErrorResponse "Publisher already exists"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /publishers [post]
+func (h *PublisherHandler) CreatePublisher(c *gin.Context) {
+	var req request.CreatePublisherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	publisher, err := h.svc.CreatePublisher(c.Request.Context(), req.Name)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := publisher.ConvertToResponse()
+	c.JSON(http.StatusCreated, resp)
+}
+
+// UpdatePublisher godoc
+// @Summary Update an existing publisher
+// @Description Modify the details of an existing publisher using its ID
+// @Tags Publishers
+// @Accept json
+// @Produce json
+// @Param id path int true "Publisher ID"
+// @Param publisher body request.UpdatePublisherRequest true "Publisher data to update"
+// @Success 200 {object} response.PublisherResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 404 {object} response.ErrorResponse "Publisher not found"
+// @Failure 409 {object} response.ErrorResponse "Publisher already exists"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /publishers/{id} [put]
+func (h *PublisherHandler) UpdatePublisher(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.UpdatePublisherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	publisher, err := h.svc.UpdatePublisher(c.Request.Context(), id, req.Name)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := publisher.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeletePublisher godoc
+// @Summary Delete a publisher
+// @Description Remove a publisher that no book refers to
+// @Tags Publishers
+// @Accept json
+// @Produce json
+// @Param id path int true "Publisher ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Publisher not found"
+// @Failure 409 {object} response.ErrorResponse "Publisher still has books"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /publishers/{id} [delete]
+func (h *PublisherHandler) DeletePublisher(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	err = h.svc.DeletePublisher(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PublisherHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPublisherNotFound):
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrDuplicatePublisher), errors.Is(err, service.ErrPublisherHasBooks):
+		c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+}
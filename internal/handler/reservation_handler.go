@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/request"
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReservationHandler handles reservation-related HTTP requests.
+type ReservationHandler struct {
+	svc service.ReservationService
+}
+
+// NewReservationHandler creates a new ReservationHandler.
+func NewReservationHandler(svc service.ReservationService) *ReservationHandler {
+	return &ReservationHandler{svc: svc}
+}
+
+// ListBookReservations godoc
+// @Summary List the reservation queue of a book
+// @Description Get the ready reservations of a book followed by the pending ones in queue order
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Success 200 {array} response.ReservationResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Book not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/{id}/reservations [get]
+func (h *ReservationHandler) ListBookReservations(c *gin.Context) {
+	idStr := c.Param("id")
+	bookID, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	reservations, err := h.svc.ListBookReservations(c.Request.Context(), bookID)
+	if err != nil {
+		if errors.Is(err, service.ErrBookNotFound) {
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.ReservationResponse, len(reservations))
+	for i, r := range reservations {
+		resp[i] = r.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateReservation godoc
+// @Summary Reserve a book
+// @Description Put a patron in the queue of a book whose copies are all out
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param reservation body request.CreateReservationRequest true "Reservation to create"
+// @Success 201 {object} response.ReservationResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 404 {object} response.ErrorResponse "Book or member not found"
+// @Failure 409 {object} response.ErrorResponse "Book available, member not allowed to borrow or already in the queue"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/{id}/reservations [post]
+func (h *ReservationHandler) CreateReservation(c *gin.Context) {
+	idStr := c.Param("id")
+	bookID, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.CreateReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	reservation, err := h.svc.CreateReservation(c.Request.Context(), bookID, req.MemberID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBookNotFound), errors.Is(err, service.ErrMemberNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrBookAvailable), errors.Is(err, service.ErrDuplicateReservation),
+			errors.Is(err, service.ErrMemberSuspended), errors.Is(err, service.ErrMembershipExpired):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	resp := reservation.ConvertToResponse()
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetReservation godoc
+// @Summary Get a reservation by ID
+// @Description Retrieve a single reservation and its place in the queue
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Param id path int true "Reservation ID"
+// @Success 200 {object} response.ReservationResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /reservations/{id} [get]
+func (h *ReservationHandler) GetReservation(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	reservation, err := h.svc.GetReservation(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if reservation == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "not found"})
+		return
+	}
+
+	resp := reservation.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// CancelReservation godoc
+// @Summary Cancel a reservation
+// @Description Leave the queue of a book. A copy held for the reservation goes to the next patron.
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Param id path int true "Reservation ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Reservation not found"
+// @Failure 409 {object} response.ErrorResponse "Reservation no longer active"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /reservations/{id} [delete]
+func (h *ReservationHandler) CancelReservation(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	err = h.svc.CancelReservation(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReservationNotFound):
+			c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrReservationNotActive):
+			c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler handles the full-text search requests.
+type SearchHandler struct {
+	svc service.SearchService
+}
+
+// NewSearchHandler creates a new SearchHandler.
+func NewSearchHandler(svc service.SearchService) *SearchHandler {
+	return &SearchHandler{svc: svc}
+}
+
+// Search godoc
+// @Summary Search books and authors
+// @Description Full-text search over book titles and author names, ranked by relevance. The query accepts web search syntax: "quoted phrases", or and -excluded words. Words are stemmed in the language configured for the deployment.
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query"
+// @Param type query string false "Comma separated kinds of records to search: book, author. Both by default"
+// @Param limit query int false "Maximum number of results, 20 by default and at most 100"
+// @Success 200 {array} response.SearchHitResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	var types []string
+	if t := c.Query("type"); t != "" {
+		types = strings.Split(t, ",")
+	}
+
+	limit := 0
+	if l := c.Query("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid limit"})
+			return
+		}
+	}
+
+	hits, err := h.svc.Search(c.Request.Context(), c.Query("q"), types, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrEmptySearchQuery) || errors.Is(err, service.ErrInvalidSearchType) {
+			c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.SearchHitResponse, len(hits))
+	for i, hit := range hits {
+		resp[i] = hit.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/request"
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagHandler handles tag-related HTTP requests.
+type TagHandler struct {
+	svc service.TagService
+}
+
+// NewTagHandler creates a new TagHandler.
+func NewTagHandler(svc service.TagService) *TagHandler {
+	return &TagHandler{svc: svc}
+}
+
+// ListTags godoc
+// @Summary List tags
+// @Description Get a list of tags with optional filters, sorts, and selected fields
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param filter query []string false "Filter conditions"
+// @Param sort query []string false "Sort conditions"
+// @Param fields query string false "Fields to select"
+// @Success 200 {array} response.TagResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /tags [get]
+func (h *TagHandler) ListTags(c *gin.Context) {
+	filters := c.QueryArray("filter")
+	sorts := c.QueryArray("sort")
+	fields := c.Query("fields")
+
+	tags, err := h.svc.ListTags(c.Request.Context(), filters, sorts, fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.TagResponse, len(tags))
+	for i, t := range tags {
+		resp[i] = t.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetTag godoc
+// @Summary Get a tag by ID
+// @Description Retrieve a single tag using its unique ID
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 200 {object} response.TagResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /tags/{id} [get]
+func (h *TagHandler) GetTag(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	tag, err := h.svc.GetTag(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if tag == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "not found"})
+		return
+	}
+
+	resp := tag.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateTag godoc
+// @Summary Create a new tag
+// @Description Add a tag. Names are stored lower case with single spaces.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param tag body request.CreateTagRequest true "Tag to create"
+// @Success 201 {object} response.TagResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 409 {object} response.ErrorResponse "Tag already exists"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /tags [post]
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req request.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tag, err := h.svc.CreateTag(c.Request.Context(), req.Name)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := tag.ConvertToResponse()
+	c.JSON(http.StatusCreated, resp)
+}
+
+// UpdateTag godoc
+// @Summary Update an existing tag
+// @Description Rename a tag
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param tag body request.UpdateTagRequest true "Tag data to update"
+// @Success 200 {object} response.TagResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 404 {object} response.ErrorResponse "Tag not found"
+// @Failure 409 {object} response.ErrorResponse "Tag already exists"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /tags/{id} [put]
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tag, err := h.svc.UpdateTag(c.Request.Context(), id, req.Name)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := tag.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteTag godoc
+// @Summary Delete a tag
+// @Description Remove a tag from the system and from all its books
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Tag not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /tags/{id} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	err = h.svc.DeleteTag(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddBookTag godoc
+// @Summary Tag a book
+// @Description Label a book with a tag, creating the tag when it does not exist yet
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param tag body request.BookTagRequest true "Tag to add"
+// @Success 200 {object} response.TagResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 404 {object} response.ErrorResponse "Book not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/{id}/tags [post]
+func (h *TagHandler) AddBookTag(c *gin.Context) {
+	idStr := c.Param("id")
+	bookID, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.BookTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	tag, err := h.svc.TagBook(c.Request.Context(), bookID, req.Name)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := tag.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// RemoveBookTag godoc
+// @Summary Remove a tag from a book
+// @Description Take a tag off a book, the tag itself is kept
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Book ID"
+// @Param tag_id path int true "Tag ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Book not found or tag not assigned"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /books/{id}/tags/{tag_id} [delete]
+func (h *TagHandler) RemoveBookTag(c *gin.Context) {
+	bookID, tagID, ok := parseBookRelationIDs(c, "tag_id")
+	if !ok {
+		return
+	}
+
+	err := h.svc.UntagBook(c.Request.Context(), bookID, tagID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TagHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrTagNotFound), errors.Is(err, service.ErrBookNotFound):
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrInvalidTag):
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrDuplicateTag):
+		c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+}
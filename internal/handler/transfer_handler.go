@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/domain/request"
+	"borrow_book/internal/domain/response"
+	"borrow_book/internal/service"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TransferHandler handles the HTTP requests moving copies between branches.
+type TransferHandler struct {
+	svc service.TransferService
+}
+
+// NewTransferHandler creates a new TransferHandler.
+func NewTransferHandler(svc service.TransferService) *TransferHandler {
+	return &TransferHandler{svc: svc}
+}
+
+// ListTransfers godoc
+// @Summary List transfers
+// @Description Get a list of transfers between branches with optional filters, sorts, and selected fields, e.g. filter=status__eq__in_transit
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param filter query []string false "Filter conditions"
+// @Param sort query []string false "Sort conditions"
+// @Param fields query string false "Fields to select"
+// @Success 200 {array} response.TransferResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /transfers [get]
+func (h *TransferHandler) ListTransfers(c *gin.Context) {
+	filters := c.QueryArray("filter")
+	sorts := c.QueryArray("sort")
+	fields := c.Query("fields")
+
+	transfers, err := h.svc.ListTransfers(c.Request.Context(), filters, sorts, fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	resp := make([]response.TransferResponse, len(transfers))
+	for i, t := range transfers {
+		resp[i] = t.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetTransfer godoc
+// @Summary Get a transfer by ID
+// @Description Retrieve a single transfer using its unique ID
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} response.TransferResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Not Found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /transfers/{id} [get]
+func (h *TransferHandler) GetTransfer(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	transfer, err := h.svc.GetTransfer(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if transfer == nil {
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: "not found"})
+		return
+	}
+
+	resp := transfer.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListTransferEvents godoc
+// @Summary List the audit trail of a transfer
+// @Description Get every step of a transfer in the order they happened
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {array} response.TransferEventResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 404 {object} response.ErrorResponse "Transfer not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /transfers/{id}/events [get]
+func (h *TransferHandler) ListTransferEvents(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	events, err := h.svc.ListTransferEvents(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := make([]response.TransferEventResponse, len(events))
+	for i, e := range events {
+		resp[i] = e.ConvertToResponse()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateTransfer godoc
+// @Summary Request a transfer
+// @Description Ask for a copy to be moved from the branch holding it to another branch
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param transfer body request.CreateTransferRequest true "Transfer to request"
+// @Success 201 {object} response.TransferResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid input or copy already at the destination"
+// @Failure 404 {object} response.ErrorResponse "Copy or branch not found"
+// @Failure 409 {object} response.ErrorResponse "Copy already has an open transfer"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /transfers [post]
+func (h *TransferHandler) CreateTransfer(c *gin.Context) {
+	var req request.CreateTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	transfer, err := h.svc.RequestTransfer(c.Request.Context(), req.CopyID, req.ToBranchID, req.Note)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := transfer.ConvertToResponse()
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ShipTransfer godoc
+// @Summary Ship a transfer
+// @Description Record that the copy left its branch. The copy must be available and stays in transit until received.
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Param step body request.TransferStepRequest false "Note for the audit trail"
+// @Success 200 {object} response.TransferResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Transfer not found"
+// @Failure 409 {object} response.ErrorResponse "Transfer not requested, copy not available or not at the branch"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /transfers/{id}/ship [post]
+func (h *TransferHandler) ShipTransfer(c *gin.Context) {
+	h.step(c, h.svc.ShipTransfer)
+}
+
+// ReceiveTransfer godoc
+// @Summary Receive a transfer
+// @Description Record that the copy arrived at its destination branch, where it goes to the reservation queue of its book or back on the shelf
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Param step body request.TransferStepRequest false "Note for the audit trail"
+// @Success 200 {object} response.TransferResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Transfer not found"
+// @Failure 409 {object} response.ErrorResponse "Transfer not in transit"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /transfers/{id}/receive [post]
+func (h *TransferHandler) ReceiveTransfer(c *gin.Context) {
+	h.step(c, h.svc.ReceiveTransfer)
+}
+
+// CancelTransfer godoc
+// @Summary Cancel a transfer
+// @Description Cancel a transfer that has not been shipped yet
+// @Tags Transfers
+// @Accept json
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Param step body request.TransferStepRequest false "Note for the audit trail"
+// @Success 200 {object} response.TransferResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Transfer not found"
+// @Failure 409 {object} response.ErrorResponse "Transfer already shipped"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /transfers/{id}/cancel [post]
+func (h *TransferHandler) CancelTransfer(c *gin.Context) {
+	h.step(c, h.svc.CancelTransfer)
+}
+
+// step runs a step of the transfer named in the path, with the note of the optional body.
+func (h *TransferHandler) step(c *gin.Context, run func(ctx context.Context, id int, note string) (*model.Transfer, error)) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: "invalid id"})
+		return
+	}
+
+	var req request.TransferStepRequest
+	if err := bindOptionalJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	transfer, err := run(c.Request.Context(), id, req.Note)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	resp := transfer.ConvertToResponse()
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TransferHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrTransferNotFound), errors.Is(err, service.ErrCopyNotFound),
+		errors.Is(err, service.ErrBranchNotFound):
+		c.JSON(http.StatusNotFound, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrTransferSameBranch):
+		c.JSON(http.StatusBadRequest, response.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrTransferExists), errors.Is(err, service.ErrInvalidTransferStep),
+		errors.Is(err, service.ErrCopyNotAvailable), errors.Is(err, service.ErrCopyBranchMismatch):
+		c.JSON(http.StatusConflict, response.ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, response.ErrorResponse{Error: err.Error()})
+	}
+}
@@ -0,0 +1,8 @@
+package postgres
+
+import "github.com/google/wire"
+
+// ProviderSetPostgres is providers.
+var ProviderSetPostgres = wire.NewSet(
+	NewTxManager,
+)
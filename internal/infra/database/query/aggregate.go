@@ -0,0 +1,199 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GroupJoin exposes a field that is not a column of a table to grouping. Join
+// is joined to the filtered rows of the table, aliased t, and Column is the
+// grouped expression, e.g. "LEFT JOIN book_authors ba ON ba.book_id = t.id"
+// and "ba.author_id".
+type GroupJoin struct {
+	Join   string
+	Column string
+}
+
+// GroupBy is a field aggregates are grouped by, or a time bucket of a time field.
+type GroupBy struct {
+	Field  string
+	Bucket string // day, week, month or year, empty for the field itself
+}
+
+// Name is the key of the group in the aggregated rows, as given to ParseGroupBy.
+func (g GroupBy) Name() string {
+	if g.Bucket == "" {
+		return g.Field
+	}
+	return fmt.Sprintf("%s(%s)", g.Bucket, g.Field)
+}
+
+// Aggregate is an aggregate function over a column, or over the rows for count.
+type Aggregate struct {
+	Func  string    // count, min, max or sum
+	Field string    // Empty for count
+	Type  FieldType // Type of the field
+}
+
+// Name is the key of the aggregate in the aggregated rows, as given to ParseAggregates.
+func (a Aggregate) Name() string {
+	if a.Field == "" {
+		return a.Func
+	}
+	return fmt.Sprintf("%s(%s)", a.Func, a.Field)
+}
+
+// timeBuckets formats the Unix times of a bucket, so that buckets sort in time order.
+var timeBuckets = map[string]string{
+	"day":   "YYYY-MM-DD",
+	"week":  `IYYY-"W"IW`,
+	"month": "YYYY-MM",
+	"year":  "YYYY",
+}
+
+var aggregateFuncs = []string{"count", "max", "min", "sum"}
+
+// ParseGroupBy reads a comma separated list of fields, or of time buckets of
+// time fields written as bucket(field), e.g. author_id,month(borrowed_at).
+func ParseGroupBy(s *Schema, raw string) ([]GroupBy, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var groups []GroupBy
+	for _, part := range strings.Split(raw, ",") {
+		fn, field, err := splitCall(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		_, isJoin := s.groupJoins[field]
+		if !s.isColumn[field] && !isJoin {
+			return nil, &UnknownValueError{Param: "group_by", Kind: "field", Value: field, Allowed: s.GroupFields()}
+		}
+		if fn != "" {
+			if _, ok := timeBuckets[fn]; !ok {
+				return nil, &UnknownValueError{Param: "group_by", Kind: "bucket", Value: fn, Allowed: sortedKeys(timeBuckets)}
+			}
+			if s.Type(field) != TypeTime {
+				return nil, fmt.Errorf("%w: %s is not a time field and cannot be grouped by %s", ErrInvalidQuery, field, fn)
+			}
+		}
+		groups = append(groups, GroupBy{Field: field, Bucket: fn})
+	}
+	return groups, nil
+}
+
+// ParseAggregates reads a comma separated list of count and of min, max and
+// sum of a column, e.g. count,sum(page_count). No aggregate counts the rows.
+func ParseAggregates(s *Schema, raw string) ([]Aggregate, error) {
+	if raw == "" {
+		return []Aggregate{{Func: "count"}}, nil
+	}
+	var aggs []Aggregate
+	for _, part := range strings.Split(raw, ",") {
+		fn, field, err := splitCall(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if fn == "" {
+			fn, field = field, ""
+		}
+		if !contains(aggregateFuncs, fn) {
+			return nil, &UnknownValueError{Param: "agg", Kind: "function", Value: fn, Allowed: aggregateFuncs}
+		}
+		if fn == "count" {
+			if field != "" {
+				return nil, fmt.Errorf("%w: count counts rows and takes no field", ErrInvalidQuery)
+			}
+			aggs = append(aggs, Aggregate{Func: fn})
+			continue
+		}
+		if !s.CanAggregate(fn, field) {
+			return nil, &UnknownValueError{Param: "agg", Kind: fn + " field", Value: field, Allowed: s.AggregateFields(fn)}
+		}
+		aggs = append(aggs, Aggregate{Func: fn, Field: field, Type: s.Type(field)})
+	}
+	return aggs, nil
+}
+
+// splitCall splits fn(field) into its function and field, and returns a lone
+// field with no function.
+func splitCall(raw string) (fn, field string, err error) {
+	open := strings.IndexByte(raw, '(')
+	if open < 0 {
+		return "", raw, nil
+	}
+	if !strings.HasSuffix(raw, ")") {
+		return "", "", fmt.Errorf("%w: missing closing parenthesis in %q", ErrInvalidQuery, raw)
+	}
+	return raw[:open], strings.TrimSpace(raw[open+1 : len(raw)-1]), nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// BuildAggregateQuery aggregates the rows matching the filters of opts by its
+// GroupBy fields, ordered by group, ignoring its sorts, fields and page. Each
+// group and aggregate is selected under its Name. Times of buckets are taken in UTC.
+func BuildAggregateQuery(tableName string, opts QueryOptions) (string, []interface{}) {
+	var (
+		selects []string
+		joins   []string
+		groupBy []string
+	)
+
+	for i, g := range opts.GroupBy {
+		column := "t." + g.Field
+		if join, ok := opts.GroupJoins[g.Field]; ok {
+			column = join.Column
+			if !contains(joins, join.Join) {
+				joins = append(joins, join.Join)
+			}
+		}
+		if g.Bucket != "" {
+			column = fmt.Sprintf("to_char(to_timestamp(%s) AT TIME ZONE 'UTC', '%s')", column, timeBuckets[g.Bucket])
+		}
+		selects = append(selects, fmt.Sprintf(`%s AS "%s"`, column, g.Name()))
+		groupBy = append(groupBy, fmt.Sprint(i+1))
+	}
+
+	for _, a := range opts.Aggregates {
+		var expr string
+		switch a.Func {
+		case "count":
+			expr = "COUNT(*)"
+		case "sum":
+			// Sums of integers come back as numeric otherwise
+			cast := "bigint"
+			if a.Type == TypeFloat {
+				cast = "float8"
+			}
+			expr = fmt.Sprintf("SUM(t.%s)::%s", a.Field, cast)
+		default:
+			expr = fmt.Sprintf("%s(t.%s)", strings.ToUpper(a.Func), a.Field)
+		}
+		selects = append(selects, fmt.Sprintf(`%s AS "%s"`, expr, a.Name()))
+	}
+
+	whereClauses, args := buildWhere(opts)
+	rows := "SELECT * FROM " + tableName
+	if len(whereClauses) > 0 {
+		rows += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM (%s) t", strings.Join(selects, ", "), rows)
+	for _, j := range joins {
+		query += " " + j
+	}
+	if len(groupBy) > 0 {
+		list := strings.Join(groupBy, ", ")
+		query += " GROUP BY " + list + " ORDER BY " + list
+	}
+	return query, args
+}
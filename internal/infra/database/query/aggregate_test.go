@@ -0,0 +1,77 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGroupBy(t *testing.T) {
+	groups, err := ParseGroupBy(testSchema, "author_id, month(created_at)")
+	assert.NoError(t, err)
+	assert.Equal(t, []GroupBy{{Field: "author_id"}, {Field: "created_at", Bucket: "month"}}, groups)
+	assert.Equal(t, "month(created_at)", groups[1].Name())
+
+	var unknown *UnknownValueError
+	_, err = ParseGroupBy(testSchema, "note")
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, []string{"author_id", "created_at", "id", "title"}, unknown.Allowed)
+
+	_, err = ParseGroupBy(testSchema, "hour(created_at)")
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, []string{"day", "month", "week", "year"}, unknown.Allowed)
+
+	_, err = ParseGroupBy(testSchema, "month(title)")
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+	_, err = ParseGroupBy(testSchema, "month(created_at")
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestParseAggregates(t *testing.T) {
+	aggs, err := ParseAggregates(testSchema, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Aggregate{{Func: "count"}}, aggs)
+
+	aggs, err = ParseAggregates(testSchema, "count,max(title),min(created_at)")
+	assert.NoError(t, err)
+	assert.Equal(t, []Aggregate{
+		{Func: "count"},
+		{Func: "max", Field: "title", Type: TypeString},
+		{Func: "min", Field: "created_at", Type: TypeTime},
+	}, aggs)
+
+	var unknown *UnknownValueError
+	_, err = ParseAggregates(testSchema, "avg(id)")
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "function", unknown.Kind)
+
+	// Ids, times and strings cannot be summed
+	_, err = ParseAggregates(testSchema, "sum(id)")
+	assert.True(t, errors.As(err, &unknown))
+	assert.Empty(t, unknown.Allowed)
+
+	_, err = ParseAggregates(testSchema, "count(id)")
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestBuildAggregateQuery(t *testing.T) {
+	opts := QueryOptions{
+		Filters:     []Filter{{Field: "title", Operator: "contains", Value: "go"}},
+		FilterExprs: testSchema.FilterExprs(),
+		GroupBy:     []GroupBy{{Field: "author_id"}, {Field: "created_at", Bucket: "year"}},
+		Aggregates:  []Aggregate{{Func: "count"}, {Func: "max", Field: "id", Type: TypeInt}, {Func: "sum", Field: "id", Type: TypeInt}},
+		GroupJoins:  testSchema.GroupJoins(),
+	}
+	q, args := BuildAggregateQuery("test_items", opts)
+	assert.Equal(t, `SELECT ia.author_id AS "author_id", `+
+		`to_char(to_timestamp(t.created_at) AT TIME ZONE 'UTC', 'YYYY') AS "year(created_at)", `+
+		`COUNT(*) AS "count", MAX(t.id) AS "max(id)", SUM(t.id)::bigint AS "sum(id)" `+
+		`FROM (SELECT * FROM test_items WHERE title ILIKE $1 ESCAPE '\') t `+
+		`LEFT JOIN item_authors ia ON ia.item_id = t.id GROUP BY 1, 2 ORDER BY 1, 2`, q)
+	assert.Equal(t, []interface{}{"%go%"}, args)
+
+	q, args = BuildAggregateQuery("test_items", QueryOptions{Aggregates: []Aggregate{{Func: "count"}}})
+	assert.Equal(t, `SELECT COUNT(*) AS "count" FROM (SELECT * FROM test_items) t`, q)
+	assert.Empty(t, args)
+}
@@ -7,54 +7,48 @@ import (
 
 func BuildSelectQuery(tableName string, opts QueryOptions) (string, []interface{}) {
 	var (
-		args         []interface{}
-		whereClauses []string
 		selectFields string
 		orderClause  string
 	)
 
+	whereClauses, args := buildWhere(opts)
+
+	// Pages are ordered on a unique key so that no row is skipped or repeated
+	sorts := opts.Sorts
+	if opts.Page.Limit > 0 {
+		sorts = stableSorts(sorts)
+	}
+
 	// Handle fields
 	if len(opts.Fields) == 0 {
 		selectFields = "*"
 	} else {
-		selectFields = strings.Join(opts.Fields, ", ")
-	}
-
-	// Handle filters
-	argIndex := 1
-	for _, fil := range opts.Filters {
-		op := ""
-		switch fil.Operator {
-		case "eq":
-			op = "="
-		case "neq":
-			op = "!="
-		case "ilike":
-			op = "ILIKE"
-		case "gt":
-			op = ">"
-		case "gte":
-			op = ">="
-		case "lt":
-			op = "<"
-		case "lte":
-			op = "<="
-		default:
-			op = "="
+		fields := opts.Fields
+		if opts.Page.Limit > 0 {
+			// The next cursor is made of the sort values of the last row
+			fields = withSortFields(fields, sorts)
 		}
+		selectFields = strings.Join(fields, ", ")
+	}
 
-		whereClauses = append(whereClauses, fmt.Sprintf("%s %s $%d", fil.Field, op, argIndex))
-		args = append(args, fil.Value)
-		argIndex++
+	// Only keep the rows after the cursor
+	if len(opts.Page.After) > 0 {
+		var cond string
+		cond, args = keysetCondition(sorts, opts.Page.After, args)
+		whereClauses = append(whereClauses, cond)
 	}
 
 	// Handle sorts
-	if len(opts.Sorts) > 0 {
+	if len(sorts) > 0 {
 		var sortExprs []string
-		for _, s := range opts.Sorts {
-			dir := "ASC"
+		for _, s := range sorts {
+			// NULLs rank above every value, the order keysetCondition expects
+			dir := "ASC NULLS LAST"
 			if s.Desc {
-				dir = "DESC"
+				dir = "DESC NULLS FIRST"
+			}
+			if s.Field == tieBreaker {
+				dir = strings.Fields(dir)[0]
 			}
 			sortExprs = append(sortExprs, fmt.Sprintf("%s %s", s.Field, dir))
 		}
@@ -68,5 +62,215 @@ func BuildSelectQuery(tableName string, opts QueryOptions) (string, []interface{
 	if orderClause != "" {
 		query += " " + orderClause
 	}
+	if opts.Page.Limit > 0 {
+		// One more row than asked tells whether there is a next page
+		query += fmt.Sprintf(" LIMIT %d", opts.Page.Limit+1)
+		if opts.Page.Offset > 0 {
+			query += fmt.Sprintf(" OFFSET %d", opts.Page.Offset)
+		}
+	}
+	return query, args
+}
+
+// BuildCountQuery counts the rows matching the filters of opts, ignoring its sorts and page.
+func BuildCountQuery(tableName string, opts QueryOptions) (string, []interface{}) {
+	whereClauses, args := buildWhere(opts)
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)
+	if len(whereClauses) > 0 {
+		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	}
 	return query, args
 }
+
+// buildWhere turns the filters and filter expression of opts into conditions
+// numbering their arguments from $1.
+func buildWhere(opts QueryOptions) ([]string, []interface{}) {
+	var (
+		args         []interface{}
+		whereClauses []string
+		cond         string
+	)
+
+	for _, fil := range opts.Filters {
+		cond, args = buildCondition(fil, opts.FilterExprs, args)
+		whereClauses = append(whereClauses, cond)
+	}
+	if opts.Where != nil {
+		cond, args = buildExpr(opts.Where, opts.FilterExprs, args)
+		whereClauses = append(whereClauses, cond)
+	}
+	return whereClauses, args
+}
+
+// buildExpr compiles a filter expression, parenthesizing its groups.
+func buildExpr(e Expr, filterExprs map[string]string, args []interface{}) (string, []interface{}) {
+	var (
+		exprs []Expr
+		sep   string
+	)
+	switch e := e.(type) {
+	case Filter:
+		return buildCondition(e, filterExprs, args)
+	case And:
+		exprs, sep = e, " AND "
+	case Or:
+		exprs, sep = e, " OR "
+	}
+
+	parts := make([]string, len(exprs))
+	for i, sub := range exprs {
+		parts[i], args = buildExpr(sub, filterExprs, args)
+	}
+	return "(" + strings.Join(parts, sep) + ")", args
+}
+
+// buildCondition returns the condition of a filter and appends its values to args.
+func buildCondition(fil Filter, filterExprs map[string]string, args []interface{}) (string, []interface{}) {
+	// Fields without an expression are compared as columns
+	expr, ok := filterExprs[fil.Field]
+	if !ok {
+		cond, args := buildComparison(fil, args)
+		return fil.Field + " " + cond, args
+	}
+
+	// Expressions match rows having a related value, so negated operators
+	// negate the positive condition rather than the related value: a book
+	// without any author matches author_id__neq, author_id__isnull matches
+	// books without authors.
+	positive, negated := negatedOperators[fil.Operator]
+	if negated {
+		fil.Operator = positive
+	}
+	cond, args := buildComparison(fil, args)
+	cond = fmt.Sprintf(expr, cond)
+	if negated {
+		cond = "NOT (" + cond + ")"
+	}
+	return cond, args
+}
+
+// negatedOperators maps the negated operators to their positive operator.
+var negatedOperators = map[string]string{
+	"neq":    "eq",
+	"nin":    "in",
+	"nilike": "ilike",
+	"isnull": "notnull",
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns, with backslash as the escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// buildComparison returns the comparison of a filter, e.g. "= $3", and appends its values to args.
+// The in, nin and between operators take a []interface{} value, see ParseFilters.
+func buildComparison(fil Filter, args []interface{}) (string, []interface{}) {
+	// placeholder appends a value and returns its parameter
+	placeholder := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	switch fil.Operator {
+	case "isnull":
+		return "IS NULL", args
+	case "notnull":
+		return "IS NOT NULL", args
+	case "in", "nin":
+		values, _ := fil.Value.([]interface{})
+		params := make([]string, len(values))
+		for i, v := range values {
+			params[i] = placeholder(v)
+		}
+		op := "IN"
+		if fil.Operator == "nin" {
+			op = "NOT IN"
+		}
+		return fmt.Sprintf("%s (%s)", op, strings.Join(params, ", ")), args
+	case "between":
+		values, _ := fil.Value.([]interface{})
+		if len(values) != 2 {
+			panic(fmt.Sprintf("query: between filter on %s needs two values", fil.Field))
+		}
+		low := placeholder(values[0])
+		return fmt.Sprintf("BETWEEN %s AND %s", low, placeholder(values[1])), args
+	case "startswith":
+		return fmt.Sprintf(`ILIKE %s ESCAPE '\'`, placeholder(likeEscaper.Replace(fmt.Sprint(fil.Value))+"%")), args
+	case "contains":
+		return fmt.Sprintf(`ILIKE %s ESCAPE '\'`, placeholder("%"+likeEscaper.Replace(fmt.Sprint(fil.Value))+"%")), args
+	}
+
+	op, ok := comparisonOperators[fil.Operator]
+	if !ok {
+		// ParseFilters rejects unknown operators, reaching here is a bug of the caller
+		panic(fmt.Sprintf("query: unknown filter operator %q", fil.Operator))
+	}
+	return op + " " + placeholder(fil.Value), args
+}
+
+// comparisonOperators maps the operators comparing to a single value to SQL.
+var comparisonOperators = map[string]string{
+	"eq":     "=",
+	"neq":    "!=",
+	"gt":     ">",
+	"gte":    ">=",
+	"lt":     "<",
+	"lte":    "<=",
+	"ilike":  "ILIKE",
+	"nilike": "NOT ILIKE",
+}
+
+// keysetCondition selects the rows coming after the given sort values, e.g.
+// "((title > $1 OR title IS NULL) OR (title = $1 AND id > $2))", and appends
+// the values to args. NULLs rank above every value, as in the ORDER BY of
+// BuildSelectQuery: last in ascending order and first in descending order.
+func keysetCondition(sorts []Sort, values []interface{}, args []interface{}) (string, []interface{}) {
+	var (
+		alternatives []string
+		equals       []string
+	)
+	for i, s := range sorts {
+		var after, equal string
+		if values[i] == nil {
+			equal = s.Field + " IS NULL"
+			if s.Desc {
+				after = s.Field + " IS NOT NULL"
+			}
+		} else {
+			args = append(args, values[i])
+			param := fmt.Sprintf("$%d", len(args))
+			equal = s.Field + " = " + param
+			switch {
+			case s.Desc:
+				after = s.Field + " < " + param
+			case s.Field == tieBreaker:
+				after = s.Field + " > " + param
+			default:
+				after = fmt.Sprintf("(%s > %s OR %s IS NULL)", s.Field, param, s.Field)
+			}
+		}
+		// Nothing follows a NULL in ascending order but the rows equal to it
+		if after != "" {
+			cond := append(equals[:len(equals):len(equals)], after)
+			alternatives = append(alternatives, "("+strings.Join(cond, " AND ")+")")
+		}
+		equals = append(equals, equal)
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// withSortFields adds the sorted columns missing from fields.
+func withSortFields(fields []string, sorts []Sort) []string {
+	result := append([]string(nil), fields...)
+	for _, s := range sorts {
+		found := false
+		for _, f := range fields {
+			if f == s.Field {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, s.Field)
+		}
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSelectQueryOperators(t *testing.T) {
+	filters, err := ParseFilters(testSchema, []string{
+		"id__in__1, 2,3",
+		"id__nin__4",
+		"id__between__10,20",
+		"title__nilike__%draft%",
+		"title__startswith__50%_off",
+		`title__contains__a\b`,
+		"title__notnull__",
+		"author_id__in__5,6",
+	})
+	assert.NoError(t, err)
+
+	q, args := BuildSelectQuery("test_items", QueryOptions{Filters: filters, FilterExprs: testSchema.FilterExprs()})
+	assert.Equal(t, "SELECT * FROM test_items WHERE id IN ($1, $2, $3) AND id NOT IN ($4) AND id BETWEEN $5 AND $6"+
+		" AND title NOT ILIKE $7 AND title ILIKE $8 ESCAPE '\\' AND title ILIKE $9 ESCAPE '\\' AND title IS NOT NULL"+
+		" AND id IN (SELECT item_id FROM item_authors WHERE author_id IN ($10, $11))", q)
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(3), int64(4), int64(10), int64(20),
+		"%draft%", `50\%\_off%`, `%a\\b%`, int64(5), int64(6)}, args)
+}
+
+func TestBuildSelectQueryNegatedFilterExprs(t *testing.T) {
+	tests := []struct {
+		filter Filter
+		want   string
+		args   []interface{}
+	}{
+		{Filter{"author_id", "neq", int64(5)}, "NOT (id IN (SELECT item_id FROM item_authors WHERE author_id = $1))", []interface{}{int64(5)}},
+		{Filter{"author_id", "nin", []interface{}{int64(5), int64(6)}}, "NOT (id IN (SELECT item_id FROM item_authors WHERE author_id IN ($1, $2)))", []interface{}{int64(5), int64(6)}},
+		{Filter{"author_id", "nilike", "%x%"}, "NOT (id IN (SELECT item_id FROM item_authors WHERE author_id ILIKE $1))", []interface{}{"%x%"}},
+		{Filter{"author_id", "isnull", nil}, "NOT (id IN (SELECT item_id FROM item_authors WHERE author_id IS NOT NULL))", nil},
+		{Filter{"author_id", "notnull", nil}, "id IN (SELECT item_id FROM item_authors WHERE author_id IS NOT NULL)", nil},
+		{Filter{"author_id", "eq", int64(5)}, "id IN (SELECT item_id FROM item_authors WHERE author_id = $1)", []interface{}{int64(5)}},
+	}
+	for _, tt := range tests {
+		q, args := BuildSelectQuery("test_items", QueryOptions{Filters: []Filter{tt.filter}, FilterExprs: testSchema.FilterExprs()})
+		assert.Equal(t, "SELECT * FROM test_items WHERE "+tt.want, q, tt.filter.Operator)
+		assert.Equal(t, tt.args, args, tt.filter.Operator)
+	}
+
+	// RSQL negations take the same path
+	expr, err := ParseExpr(testSchema, "author_id!=5;author_id=out=(5,6)")
+	assert.NoError(t, err)
+	q, args := BuildSelectQuery("test_items", QueryOptions{Where: expr, FilterExprs: testSchema.FilterExprs()})
+	assert.Equal(t, "SELECT * FROM test_items WHERE (NOT (id IN (SELECT item_id FROM item_authors WHERE author_id = $1))"+
+		" AND NOT (id IN (SELECT item_id FROM item_authors WHERE author_id IN ($2, $3))))", q)
+	assert.Equal(t, []interface{}{int64(5), int64(5), int64(6)}, args)
+}
+
+func TestParseFiltersValues(t *testing.T) {
+	_, err := ParseFilters(testSchema, []string{"id__between__1"})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+
+	_, err = ParseFilters(testSchema, []string{"id__in__"})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+
+	_, err = ParseFilters(testSchema, []string{"id__like__1"})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
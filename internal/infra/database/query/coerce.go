@@ -0,0 +1,111 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// FieldType is the type filter values of a field are converted to.
+type FieldType int
+
+const (
+	TypeString FieldType = iota
+	TypeInt
+	TypeFloat
+	TypeBool
+	TypeTime // Unix time in seconds, given as YYYY-MM-DD, RFC 3339 or seconds
+)
+
+func (t FieldType) String() string {
+	switch t {
+	case TypeInt:
+		return "an integer"
+	case TypeFloat:
+		return "a number"
+	case TypeBool:
+		return "a boolean"
+	case TypeTime:
+		return "a date (YYYY-MM-DD) or RFC 3339 time"
+	}
+	return "a string"
+}
+
+// patternOperators only apply to text fields.
+var patternOperators = map[string]bool{"ilike": true, "nilike": true, "startswith": true, "contains": true}
+
+// coerceFilter converts the values of a parsed filter to the type of its field,
+// so that they compare to the stored values.
+func (s *Schema) coerceFilter(f Filter) (Filter, error) {
+	typ := s.Type(f.Field)
+	if f.Operator == "isnull" || f.Operator == "notnull" {
+		return f, nil // The value, if any, is not compared to anything
+	}
+	if patternOperators[f.Operator] {
+		if typ != TypeString {
+			return Filter{}, &UnknownValueError{
+				Param: "filter", Kind: "operator", Value: f.Operator,
+				Allowed: operatorsFor(typ),
+			}
+		}
+		return f, nil
+	}
+
+	switch v := f.Value.(type) {
+	case string:
+		value, err := coerceValue(typ, v)
+		if err != nil {
+			return Filter{}, fmt.Errorf("%w: invalid value %q for %s, expected %s", ErrInvalidQuery, v, f.Field, typ)
+		}
+		f.Value = value
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, raw := range v {
+			str, _ := raw.(string)
+			value, err := coerceValue(typ, str)
+			if err != nil {
+				return Filter{}, fmt.Errorf("%w: invalid value %q for %s, expected %s", ErrInvalidQuery, str, f.Field, typ)
+			}
+			values[i] = value
+		}
+		f.Value = values
+	}
+	return f, nil
+}
+
+// coerceValue parses a raw filter value as typ.
+func coerceValue(typ FieldType, raw string) (interface{}, error) {
+	switch typ {
+	case TypeInt:
+		return strconv.ParseInt(raw, 10, 64)
+	case TypeFloat:
+		return strconv.ParseFloat(raw, 64)
+	case TypeBool:
+		return strconv.ParseBool(raw)
+	case TypeTime:
+		return parseTime(raw)
+	}
+	return raw, nil
+}
+
+// parseTime reads a date, taken at midnight UTC, an RFC 3339 time or Unix seconds.
+func parseTime(raw string) (int64, error) {
+	if t, err := time.Parse("2006-01-02", raw); err == nil {
+		return t.Unix(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.Unix(), nil
+	}
+	return strconv.ParseInt(raw, 10, 64)
+}
+
+// operatorsFor lists the operators applying to fields of a type.
+func operatorsFor(typ FieldType) []string {
+	var ops []string
+	for _, op := range operators {
+		if typ == TypeString || !patternOperators[op] {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
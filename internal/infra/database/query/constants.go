@@ -13,6 +13,20 @@ type Sort struct {
 
 type QueryOptions struct {
 	Filters []Filter
+	Where   Expr // Joined to Filters with AND, nil for none
 	Sorts   []Sort
 	Fields  []string
+	// FilterExprs maps filter fields that are not columns of the table to an SQL
+	// condition matching rows having a related value. Its %s verb receives the
+	// positive comparison, e.g. "= $1" or "IS NOT NULL"; negated operators and
+	// isnull wrap the whole condition in NOT (...).
+	FilterExprs map[string]string
+	// Page limits the rows returned, the zero Page returns every row.
+	Page Page
+	// GroupBy and Aggregates are the groups and aggregates of BuildAggregateQuery.
+	GroupBy    []GroupBy
+	Aggregates []Aggregate
+	// GroupJoins maps group fields that are not columns of the table to the
+	// join exposing them.
+	GroupJoins map[string]GroupJoin
 }
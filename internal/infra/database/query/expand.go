@@ -0,0 +1,83 @@
+package query
+
+import (
+	"sort"
+	"strings"
+)
+
+// Expand is the set of relations to embed in the returned objects, as dotted
+// paths from them, e.g. book.author embeds the authors of the book of a borrow.
+type Expand map[string]bool
+
+// ParseExpand reads a comma separated list of relations among the allowed
+// ones. Expanding a nested relation expands the relations leading to it.
+func ParseExpand(raw string, allowed ...string) (Expand, error) {
+	expand := make(Expand)
+	if raw == "" {
+		return expand, nil
+	}
+	for _, path := range strings.Split(raw, ",") {
+		path = strings.TrimSpace(path)
+		if !contains(allowed, path) {
+			sorted := append([]string(nil), allowed...)
+			sort.Strings(sorted)
+			return nil, &UnknownValueError{Param: "expand", Kind: "relation", Value: path, Allowed: sorted}
+		}
+		for i := range path {
+			if path[i] == '.' {
+				expand[path[:i]] = true
+			}
+		}
+		expand[path] = true
+	}
+	return expand, nil
+}
+
+// Has reports whether the relation is expanded.
+func (e Expand) Has(path string) bool {
+	return e[path]
+}
+
+// Sub returns the relations expanded below a relation, relative to it.
+func (e Expand) Sub(path string) Expand {
+	sub := make(Expand)
+	for p := range e {
+		if rest, ok := strings.CutPrefix(p, path+"."); ok {
+			sub[rest] = true
+		}
+	}
+	return sub
+}
+
+// String lists the expanded relations the way ParseExpand reads them.
+func (e Expand) String() string {
+	paths := make([]string, 0, len(e))
+	for p := range e {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return strings.Join(paths, ",")
+}
+
+// RequireFields adds the columns an expansion reads to selected fields. No
+// selected fields stands for all columns and is left as is.
+func RequireFields(fields []string, required ...string) []string {
+	if len(fields) == 0 {
+		return fields
+	}
+	for _, f := range required {
+		if !contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpand(t *testing.T) {
+	allowed := []string{"member", "book", "book.author"}
+
+	e, err := ParseExpand("", allowed...)
+	assert.NoError(t, err)
+	assert.Empty(t, e)
+
+	e, err = ParseExpand("book.author, member", allowed...)
+	assert.NoError(t, err)
+	assert.Equal(t, Expand{"book": true, "book.author": true, "member": true}, e)
+	assert.True(t, e.Has("book"))
+	assert.Equal(t, Expand{"author": true}, e.Sub("book"))
+	assert.Empty(t, e.Sub("member"))
+	assert.Equal(t, "book,book.author,member", e.String())
+
+	var unknown *UnknownValueError
+	_, err = ParseExpand("book,author", allowed...)
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "author", unknown.Value)
+	assert.Equal(t, []string{"book", "book.author", "member"}, unknown.Allowed)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestRequireFields(t *testing.T) {
+	assert.Nil(t, RequireFields(nil, "id"))
+	assert.Equal(t, []string{"title", "id"}, RequireFields([]string{"title"}, "id"))
+	assert.Equal(t, []string{"id", "title"}, RequireFields([]string{"id", "title"}, "id"))
+}
@@ -0,0 +1,148 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Assignment sets a column in an INSERT or UPDATE statement.
+type Assignment struct {
+	Column string
+	Value  interface{}
+}
+
+// Raw is an SQL expression assigned as is, e.g. Raw("renewal_count + 1").
+// It must never hold input of a client.
+type Raw string
+
+// Assignments lists the columns of model, a struct or a pointer to one, with
+// their values, in the order of its fields. Columns are read from db tags as
+// in Register, and the omitted ones are left out, e.g. a serial id.
+func Assignments(model interface{}, omit ...string) []Assignment {
+	v := structValue(model)
+	var set []Assignment
+	for i := 0; i < v.NumField(); i++ {
+		column := columnName(v.Type().Field(i))
+		if column == "" || contains(omit, column) {
+			continue
+		}
+		set = append(set, Assignment{Column: column, Value: v.Field(i).Interface()})
+	}
+	return set
+}
+
+// Changes lists the columns whose values differ from old to updated, two
+// values of the same struct type, with their updated values. Updating only
+// these columns leaves concurrent changes to the others in place.
+func Changes(old, updated interface{}, omit ...string) []Assignment {
+	o, u := structValue(old), structValue(updated)
+	if o.Type() != u.Type() {
+		panic(fmt.Sprintf("query: changes between %s and %s", o.Type(), u.Type()))
+	}
+	var set []Assignment
+	for i := 0; i < u.NumField(); i++ {
+		column := columnName(u.Type().Field(i))
+		if column == "" || contains(omit, column) {
+			continue
+		}
+		if !reflect.DeepEqual(o.Field(i).Interface(), u.Field(i).Interface()) {
+			set = append(set, Assignment{Column: column, Value: u.Field(i).Interface()})
+		}
+	}
+	return set
+}
+
+// BuildInsertQuery inserts a row with the assigned columns, returning the
+// given columns, e.g. the generated id.
+func BuildInsertQuery(tableName string, values []Assignment, returning ...string) (string, []interface{}) {
+	if len(values) == 0 {
+		panic(fmt.Sprintf("query: insert into %s without values", tableName))
+	}
+	columns := make([]string, len(values))
+	params := make([]string, len(values))
+	var args []interface{}
+	for i, a := range values {
+		columns[i] = a.Column
+		params[i], args = assignedValue(a, args)
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", tableName, strings.Join(columns, ", "), strings.Join(params, ", "))
+	return query + returningClause(returning), args
+}
+
+// BuildUpdateQuery sets the assigned columns of the rows matching every filter
+// of where, returning the given columns.
+func BuildUpdateQuery(tableName string, set []Assignment, where []Filter, returning ...string) (string, []interface{}) {
+	if len(set) == 0 {
+		panic(fmt.Sprintf("query: update of %s without values", tableName))
+	}
+	assignments := make([]string, len(set))
+	var args []interface{}
+	for i, a := range set {
+		var value string
+		value, args = assignedValue(a, args)
+		assignments[i] = a.Column + "=" + value
+	}
+	conds, args := mutationWhere(tableName, where, args)
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", tableName, strings.Join(assignments, ", "), conds)
+	return query + returningClause(returning), args
+}
+
+// BuildDeleteQuery deletes the rows matching every filter of where, returning the given columns.
+func BuildDeleteQuery(tableName string, where []Filter, returning ...string) (string, []interface{}) {
+	conds, args := mutationWhere(tableName, where, nil)
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s", tableName, conds)
+	return query + returningClause(returning), args
+}
+
+// mutationWhere joins the conditions of an update or delete, which must have some
+// so that a missing filter never changes every row of the table.
+func mutationWhere(tableName string, where []Filter, args []interface{}) (string, []interface{}) {
+	if len(where) == 0 {
+		panic(fmt.Sprintf("query: update or delete of %s without conditions", tableName))
+	}
+	conds := make([]string, len(where))
+	for i, fil := range where {
+		conds[i], args = buildCondition(fil, nil, args)
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// assignedValue returns the parameter of an assigned value and appends the value
+// to args, or returns Raw expressions as they are.
+func assignedValue(a Assignment, args []interface{}) (string, []interface{}) {
+	if raw, ok := a.Value.(Raw); ok {
+		return string(raw), args
+	}
+	args = append(args, a.Value)
+	return fmt.Sprintf("$%d", len(args)), args
+}
+
+func returningClause(columns []string) string {
+	if len(columns) == 0 {
+		return ""
+	}
+	return " RETURNING " + strings.Join(columns, ", ")
+}
+
+// structValue dereferences a pointer to a struct.
+func structValue(model interface{}) reflect.Value {
+	v := reflect.ValueOf(model)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("query: %T is not a struct", model))
+	}
+	return v
+}
+
+// columnName returns the column of a struct field from its db tag, or an empty
+// string for fields without one and fields tagged db:"-".
+func columnName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("db"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
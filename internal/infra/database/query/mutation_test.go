@@ -0,0 +1,64 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignments(t *testing.T) {
+	createdAt := int64(946684800)
+	item := testItem{ID: 7, Title: "Go", CreatedAt: &createdAt, Authors: []string{"Pike"}, Note: "untagged"}
+
+	assert.Equal(t, []Assignment{
+		{Column: "title", Value: "Go"},
+		{Column: "created_at", Value: &createdAt},
+	}, Assignments(&item, "id"))
+}
+
+func TestChanges(t *testing.T) {
+	createdAt, later := int64(946684800), int64(946684800)
+	old := testItem{ID: 7, Title: "Go", CreatedAt: &createdAt}
+
+	// Pointers are compared by the values they point to
+	updated := old
+	updated.CreatedAt = &later
+	updated.Note = "untagged"
+	assert.Empty(t, Changes(old, updated, "id"))
+
+	updated.Title = "Go 2"
+	updated.CreatedAt = nil
+	assert.Equal(t, []Assignment{
+		{Column: "title", Value: "Go 2"},
+		{Column: "created_at", Value: (*int64)(nil)},
+	}, Changes(old, updated, "id"))
+
+	assert.Panics(t, func() { Changes(old, Filter{}) })
+}
+
+func TestBuildInsertQuery(t *testing.T) {
+	q, args := BuildInsertQuery("test_items", []Assignment{{Column: "title", Value: "Go"}, {Column: "created_at", Value: nil}}, "id")
+	assert.Equal(t, "INSERT INTO test_items (title, created_at) VALUES ($1, $2) RETURNING id", q)
+	assert.Equal(t, []interface{}{"Go", nil}, args)
+
+	assert.Panics(t, func() { BuildInsertQuery("test_items", nil) })
+}
+
+func TestBuildUpdateQuery(t *testing.T) {
+	q, args := BuildUpdateQuery("test_items",
+		[]Assignment{{Column: "title", Value: "Go"}, {Column: "revision", Value: Raw("revision + 1")}},
+		[]Filter{{Field: "id", Operator: "eq", Value: 7}, {Field: "deleted_at", Operator: "isnull"}},
+		"id", "revision")
+	assert.Equal(t, "UPDATE test_items SET title=$1, revision=revision + 1 WHERE id = $2 AND deleted_at IS NULL RETURNING id, revision", q)
+	assert.Equal(t, []interface{}{"Go", 7}, args)
+
+	assert.Panics(t, func() { BuildUpdateQuery("test_items", []Assignment{{Column: "title", Value: "Go"}}, nil) })
+}
+
+func TestBuildDeleteQuery(t *testing.T) {
+	q, args := BuildDeleteQuery("test_items", []Filter{{Field: "id", Operator: "in", Value: []interface{}{1, 2}}})
+	assert.Equal(t, "DELETE FROM test_items WHERE id IN ($1, $2)", q)
+	assert.Equal(t, []interface{}{1, 2}, args)
+
+	assert.Panics(t, func() { BuildDeleteQuery("test_items", nil) })
+}
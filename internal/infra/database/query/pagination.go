@@ -0,0 +1,164 @@
+package query
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// tieBreaker is the unique, never NULL column ending the order of every page.
+const tieBreaker = "id"
+
+// PageRequest holds the pagination parameters of a list request.
+type PageRequest struct {
+	Limit     int    // 0 for the default page size
+	Offset    int    // Rows to skip, cannot be combined with Cursor
+	Cursor    string // Next cursor of the previous page, empty for the first page
+	WithTotal bool   // Count the rows matching the filters
+}
+
+// Page selects the rows of a list, by offset or after a keyset cursor.
+// NULLs in a sorted column come last in ascending order and first in descending order.
+type Page struct {
+	Limit     int           // 0 for every row
+	Offset    int           // Rows to skip
+	After     []interface{} // Sort values of the last row of the previous page, tie breaker included
+	WithTotal bool
+}
+
+// PageInfo describes the page returned for a list request.
+type PageInfo struct {
+	Limit      int
+	Offset     int
+	NextCursor string   // Empty on the last page
+	Total      *int     // Nil unless the total was requested
+	Fields     []string // Columns the rows were selected with, nil for every column
+}
+
+// HasMore reports whether another page follows.
+func (p PageInfo) HasMore() bool {
+	return p.NextCursor != ""
+}
+
+// cursor is the content of an opaque cursor.
+type cursor struct {
+	Sort   string        `json:"s"` // Order the values were taken in
+	Values []interface{} `json:"v"`
+}
+
+// NewPage checks the pagination parameters of a list sorted by sorts. Limits
+// above maxLimit are refused and a missing limit defaults to defaultLimit.
+func NewPage(req PageRequest, sorts []Sort, defaultLimit, maxLimit int) (Page, error) {
+	page := Page{Limit: req.Limit, Offset: req.Offset, WithTotal: req.WithTotal}
+	if page.Limit == 0 {
+		page.Limit = defaultLimit
+	}
+	if page.Limit < 0 || page.Limit > maxLimit {
+		return Page{}, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, maxLimit)
+	}
+	if page.Offset < 0 {
+		return Page{}, fmt.Errorf("%w: offset must not be negative", ErrInvalidQuery)
+	}
+	if req.Cursor == "" {
+		return page, nil
+	}
+	if page.Offset > 0 {
+		return Page{}, fmt.Errorf("%w: offset and cursor cannot be combined", ErrInvalidQuery)
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(req.Cursor)
+	if err != nil {
+		return Page{}, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	var c cursor
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber() // Keep integers exact
+	if err := dec.Decode(&c); err != nil {
+		return Page{}, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	stable := stableSorts(sorts)
+	if c.Sort != sortKey(stable) || len(c.Values) != len(stable) {
+		return Page{}, fmt.Errorf("%w: cursor was issued for another sort", ErrInvalidQuery)
+	}
+	page.After = c.Values
+	return page, nil
+}
+
+// Paginate trims the extra row fetched by BuildSelectQuery from rows and
+// describes the page, with the cursor of the next one if any.
+func Paginate[T any](rows []T, opts QueryOptions) ([]T, PageInfo, error) {
+	info := PageInfo{Limit: opts.Page.Limit, Offset: opts.Page.Offset, Fields: opts.Fields}
+	if opts.Page.Limit == 0 || len(rows) <= opts.Page.Limit {
+		return rows, info, nil
+	}
+	rows = rows[:opts.Page.Limit]
+
+	next, err := encodeCursor(stableSorts(opts.Sorts), rows[len(rows)-1])
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	info.NextCursor = next
+	return rows, info, nil
+}
+
+// encodeCursor makes the cursor of the rows following row, a struct with db tags.
+func encodeCursor(sorts []Sort, row interface{}) (string, error) {
+	v := reflect.Indirect(reflect.ValueOf(row))
+	values := make([]interface{}, len(sorts))
+	for i, s := range sorts {
+		field, ok := fieldByColumn(v, s.Field)
+		if !ok {
+			return "", fmt.Errorf("cannot make cursor: no field for column %s", s.Field)
+		}
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				values[i] = nil
+				continue
+			}
+			field = field.Elem()
+		}
+		values[i] = field.Interface()
+	}
+
+	raw, err := json.Marshal(cursor{Sort: sortKey(sorts), Values: values})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// fieldByColumn returns the field of struct v tagged with the column.
+func fieldByColumn(v reflect.Value, column string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if strings.Split(t.Field(i).Tag.Get("db"), ",")[0] == column {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// stableSorts ends sorts with the tie breaker, keeping the order of equal rows stable.
+func stableSorts(sorts []Sort) []Sort {
+	for _, s := range sorts {
+		if s.Field == tieBreaker {
+			return sorts
+		}
+	}
+	return append(sorts[:len(sorts):len(sorts)], Sort{Field: tieBreaker})
+}
+
+// sortKey identifies an order, e.g. "title,-id".
+func sortKey(sorts []Sort) string {
+	keys := make([]string, len(sorts))
+	for i, s := range sorts {
+		keys[i] = s.Field
+		if s.Desc {
+			keys[i] = "-" + s.Field
+		}
+	}
+	return strings.Join(keys, ",")
+}
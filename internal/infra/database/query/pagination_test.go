@@ -0,0 +1,95 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSelectQueryPage(t *testing.T) {
+	opts := QueryOptions{
+		Filters: []Filter{{Field: "title", Operator: "ilike", Value: "%go%"}},
+		Sorts:   []Sort{{Field: "title", Desc: true}},
+		Fields:  []string{"title"},
+		Page:    Page{Limit: 10, After: []interface{}{"Go", 7}},
+	}
+	q, args := BuildSelectQuery("test_items", opts)
+	assert.Equal(t, "SELECT title, id FROM test_items WHERE title ILIKE $1 AND ((title < $2) OR (title = $2 AND id > $3)) "+
+		"ORDER BY title DESC NULLS FIRST, id ASC LIMIT 11", q)
+	assert.Equal(t, []interface{}{"%go%", "Go", 7}, args)
+
+	q, args = BuildCountQuery("test_items", opts)
+	assert.Equal(t, "SELECT COUNT(*) FROM test_items WHERE title ILIKE $1", q)
+	assert.Equal(t, []interface{}{"%go%"}, args)
+
+	q, _ = BuildSelectQuery("test_items", QueryOptions{Page: Page{Limit: 5, Offset: 20}})
+	assert.Equal(t, "SELECT * FROM test_items ORDER BY id ASC LIMIT 6 OFFSET 20", q)
+}
+
+func TestPaginate(t *testing.T) {
+	sorts := []Sort{{Field: "title"}}
+	page, err := NewPage(PageRequest{Limit: 2}, sorts, 50, 100)
+	assert.NoError(t, err)
+
+	rows := []testItem{{ID: 1, Title: "A"}, {ID: 2, Title: "B"}, {ID: 3, Title: "C"}}
+	opts := QueryOptions{Sorts: sorts, Fields: []string{"title"}, Page: page}
+	got, info, err := Paginate(rows, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, rows[:2], got)
+	assert.True(t, info.HasMore())
+	assert.Equal(t, []string{"title"}, info.Fields)
+
+	// The cursor resumes after the last row of the page
+	cursor := info.NextCursor
+	next, err := NewPage(PageRequest{Limit: 2, Cursor: cursor}, sorts, 50, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(next.After))
+	assert.Equal(t, "B", next.After[0])
+	assert.Equal(t, json.Number("2"), next.After[1])
+
+	got, info, err = Paginate(rows[2:], QueryOptions{Sorts: sorts, Page: next})
+	assert.NoError(t, err)
+	assert.Equal(t, rows[2:], got)
+	assert.False(t, info.HasMore())
+
+	_, err = NewPage(PageRequest{Cursor: "not a cursor"}, sorts, 50, 100)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+	_, err = NewPage(PageRequest{Cursor: cursor}, []Sort{{Field: "title", Desc: true}}, 50, 100)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+	_, err = NewPage(PageRequest{Offset: 10, Cursor: cursor}, sorts, 50, 100)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+	_, err = NewPage(PageRequest{Limit: 101}, sorts, 50, 100)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestPaginateNullSortValue(t *testing.T) {
+	created := int64(5)
+	rows := []testItem{{ID: 1, CreatedAt: &created}, {ID: 2}, {ID: 3}}
+
+	// Ascending, NULLs come last: after a NULL only the NULLs with a greater id follow
+	sorts := []Sort{{Field: "created_at"}}
+	_, info, err := Paginate(rows, QueryOptions{Sorts: sorts, Page: Page{Limit: 2}})
+	assert.NoError(t, err)
+	page, err := NewPage(PageRequest{Limit: 2, Cursor: info.NextCursor}, sorts, 50, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{nil, json.Number("2")}, page.After)
+
+	q, args := BuildSelectQuery("test_items", QueryOptions{Sorts: sorts, Page: page})
+	assert.Equal(t, "SELECT * FROM test_items WHERE ((created_at IS NULL AND id > $1)) "+
+		"ORDER BY created_at ASC NULLS LAST, id ASC LIMIT 3", q)
+	assert.Equal(t, []interface{}{json.Number("2")}, args)
+
+	// Ascending after a value, the NULLs follow
+	q, args = BuildSelectQuery("test_items", QueryOptions{Sorts: sorts, Page: Page{Limit: 2, After: []interface{}{int64(5), int64(1)}}})
+	assert.Equal(t, "SELECT * FROM test_items WHERE (((created_at > $1 OR created_at IS NULL)) OR (created_at = $1 AND id > $2)) "+
+		"ORDER BY created_at ASC NULLS LAST, id ASC LIMIT 3", q)
+	assert.Equal(t, []interface{}{int64(5), int64(1)}, args)
+
+	// Descending, NULLs come first and are followed by every value
+	sorts = []Sort{{Field: "created_at", Desc: true}}
+	q, args = BuildSelectQuery("test_items", QueryOptions{Sorts: sorts, Page: Page{Limit: 2, After: []interface{}{nil, int64(2)}}})
+	assert.Equal(t, "SELECT * FROM test_items WHERE ((created_at IS NOT NULL) OR (created_at IS NULL AND id > $1)) "+
+		"ORDER BY created_at DESC NULLS FIRST, id ASC LIMIT 3", q)
+	assert.Equal(t, []interface{}{int64(2)}, args)
+}
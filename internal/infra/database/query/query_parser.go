@@ -1,51 +1,136 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
 
-func ParseFilters(rawFilters []string) ([]Filter, error) {
+// ErrInvalidQuery is matched by every error of the parsers.
+var ErrInvalidQuery = errors.New("invalid query parameter")
+
+// UnknownValueError reports a field or operator of a query parameter that the
+// table does not allow, along with the values it does.
+type UnknownValueError struct {
+	Param   string // filter, sort, fields or expand
+	Kind    string // field, operator, order or relation
+	Value   string
+	Allowed []string
+}
+
+func (e *UnknownValueError) Error() string {
+	return fmt.Sprintf("unknown %s %s %q, allowed values: %s", e.Param, e.Kind, e.Value, strings.Join(e.Allowed, ", "))
+}
+
+func (e *UnknownValueError) Unwrap() error {
+	return ErrInvalidQuery
+}
+
+// operators are the filter operators understood by BuildSelectQuery.
+var operators = []string{
+	"eq", "neq", "gt", "gte", "lt", "lte",
+	"in", "nin", "between",
+	"isnull", "notnull",
+	"ilike", "nilike", "startswith", "contains",
+}
+
+func isOperator(op string) bool {
+	for _, o := range operators {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+func ParseFilters(s *Schema, rawFilters []string) ([]Filter, error) {
 	var filters []Filter
 	for _, f := range rawFilters {
 		// expected format: field__operator__value
 		parts := strings.SplitN(f, "__", 3)
 		if len(parts) != 3 {
-			return nil, fmt.Errorf("invalid filter format: %s", f)
+			return nil, fmt.Errorf("%w: invalid filter format: %s", ErrInvalidQuery, f)
 		}
 		field, op, val := parts[0], parts[1], parts[2]
-		filters = append(filters, Filter{Field: field, Operator: op, Value: val})
+		if !s.CanFilter(field) {
+			return nil, &UnknownValueError{Param: "filter", Kind: "field", Value: field, Allowed: s.FilterFields()}
+		}
+		if !isOperator(op) {
+			return nil, &UnknownValueError{Param: "filter", Kind: "operator", Value: op, Allowed: operators}
+		}
+		value, err := parseFilterValue(op, val)
+		if err != nil {
+			return nil, err
+		}
+		filter, err := s.coerceFilter(Filter{Field: field, Operator: op, Value: value})
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
 	}
 	return filters, nil
 }
 
-func ParseSorts(rawSorts []string) ([]Sort, error) {
+// parseFilterValue splits the comma separated values of the in, nin and between operators.
+func parseFilterValue(op, raw string) (interface{}, error) {
+	switch op {
+	case "in", "nin":
+		if raw == "" {
+			return nil, fmt.Errorf("%w: %s needs at least one value", ErrInvalidQuery, op)
+		}
+		return splitValues(raw), nil
+	case "between":
+		values := splitValues(raw)
+		if len(values) != 2 {
+			return nil, fmt.Errorf("%w: between needs two comma separated values, got %q", ErrInvalidQuery, raw)
+		}
+		return values, nil
+	}
+	return raw, nil
+}
+
+func splitValues(raw string) []interface{} {
+	parts := strings.Split(raw, ",")
+	values := make([]interface{}, len(parts))
+	for i, p := range parts {
+		values[i] = strings.TrimSpace(p)
+	}
+	return values
+}
+
+func ParseSorts(s *Schema, rawSorts []string) ([]Sort, error) {
 	var sorts []Sort
-	for _, s := range rawSorts {
+	for _, raw := range rawSorts {
 		// expected format: field__asc or field__desc
-		parts := strings.SplitN(s, "__", 2)
+		parts := strings.SplitN(raw, "__", 2)
 		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid sort format: %s", s)
+			return nil, fmt.Errorf("%w: invalid sort format: %s", ErrInvalidQuery, raw)
 		}
 		field, order := parts[0], parts[1]
+		if !s.HasColumn(field) {
+			return nil, &UnknownValueError{Param: "sort", Kind: "field", Value: field, Allowed: s.SortedColumns()}
+		}
 		desc := false
 		if order == "desc" {
 			desc = true
 		} else if order != "asc" {
-			return nil, fmt.Errorf("invalid sort order: %s", order)
+			return nil, &UnknownValueError{Param: "sort", Kind: "order", Value: order, Allowed: []string{"asc", "desc"}}
 		}
 		sorts = append(sorts, Sort{Field: field, Desc: desc})
 	}
 	return sorts, nil
 }
 
-func ParseFields(raw string) []string {
+func ParseFields(s *Schema, raw string) ([]string, error) {
 	if raw == "" {
-		return nil
+		return nil, nil
 	}
 	fields := strings.Split(raw, ",")
 	for i, f := range fields {
 		fields[i] = strings.TrimSpace(f)
+		if !s.HasColumn(fields[i]) {
+			return nil, &UnknownValueError{Param: "fields", Kind: "field", Value: fields[i], Allowed: s.SortedColumns()}
+		}
 	}
-	return fields
+	return fields, nil
 }
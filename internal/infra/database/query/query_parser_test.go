@@ -0,0 +1,106 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testItem struct {
+	ID        int      `db:"id"`
+	Title     string   `db:"title"`
+	CreatedAt *int64   `db:"created_at"`
+	Authors   []string `db:"-"`
+	Note      string
+}
+
+var testSchema = Register("test_items", testItem{}, map[string]string{
+	"author_id": "id IN (SELECT item_id FROM item_authors WHERE author_id %s)",
+}).WithTypes(map[string]FieldType{"author_id": TypeInt}).WithGroupJoins(map[string]GroupJoin{
+	"author_id": {Join: "LEFT JOIN item_authors ia ON ia.item_id = t.id", Column: "ia.author_id"},
+})
+
+func TestRegister(t *testing.T) {
+	assert.Equal(t, []string{"id", "title", "created_at"}, testSchema.Columns())
+	assert.Equal(t, []string{"author_id", "created_at", "id", "title"}, testSchema.FilterFields())
+	assert.Equal(t, TypeInt, testSchema.Type("id"))
+	assert.Equal(t, TypeTime, testSchema.Type("created_at"))
+	assert.Equal(t, TypeInt, testSchema.Type("author_id"))
+	assert.Same(t, testSchema, Lookup("test_items"))
+	assert.Nil(t, Lookup("unknown"))
+}
+
+func TestParseFilters(t *testing.T) {
+	filters, err := ParseFilters(testSchema, []string{"title__ilike__%go%", "author_id__eq__3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Filter{
+		{Field: "title", Operator: "ilike", Value: "%go%"},
+		{Field: "author_id", Operator: "eq", Value: int64(3)},
+	}, filters)
+
+	_, err = ParseFilters(testSchema, []string{"title;DROP TABLE books__eq__1"})
+	var unknown *UnknownValueError
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "field", unknown.Kind)
+	assert.Equal(t, []string{"author_id", "created_at", "id", "title"}, unknown.Allowed)
+
+	_, err = ParseFilters(testSchema, []string{"title__like__x"})
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "operator", unknown.Kind)
+
+	_, err = ParseFilters(testSchema, []string{"title"})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestParseSorts(t *testing.T) {
+	sorts, err := ParseSorts(testSchema, []string{"title__desc", "id__asc"})
+	assert.NoError(t, err)
+	assert.Equal(t, []Sort{{Field: "title", Desc: true}, {Field: "id"}}, sorts)
+
+	_, err = ParseSorts(testSchema, []string{"author_id__asc"})
+	assert.EqualError(t, err, `unknown sort field "author_id", allowed values: created_at, id, title`)
+
+	_, err = ParseSorts(testSchema, []string{"title__up"})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields(testSchema, "id, title")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "title"}, fields)
+
+	_, err = ParseFields(testSchema, "id,(SELECT 1)")
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestParseFiltersTypes(t *testing.T) {
+	filters, err := ParseFilters(testSchema, []string{
+		"created_at__gt__2000-01-01",
+		"created_at__lte__2000-01-01T12:00:00+02:00",
+		"created_at__lt__946684800",
+		"id__in__1,2",
+		"title__eq__42",
+		"created_at__isnull__true",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []Filter{
+		{Field: "created_at", Operator: "gt", Value: int64(946684800)},
+		{Field: "created_at", Operator: "lte", Value: int64(946720800)},
+		{Field: "created_at", Operator: "lt", Value: int64(946684800)},
+		{Field: "id", Operator: "in", Value: []interface{}{int64(1), int64(2)}},
+		{Field: "title", Operator: "eq", Value: "42"},
+		{Field: "created_at", Operator: "isnull", Value: "true"},
+	}, filters)
+
+	_, err = ParseFilters(testSchema, []string{"created_at__gt__yesterday"})
+	assert.EqualError(t, err, `invalid query parameter: invalid value "yesterday" for created_at, expected a date (YYYY-MM-DD) or RFC 3339 time`)
+
+	_, err = ParseFilters(testSchema, []string{"id__in__1,x"})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+
+	var unknown *UnknownValueError
+	_, err = ParseFilters(testSchema, []string{"id__contains__1"})
+	assert.True(t, errors.As(err, &unknown))
+	assert.NotContains(t, unknown.Allowed, "contains")
+}
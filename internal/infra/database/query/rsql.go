@@ -0,0 +1,299 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Expr is a node of a filter expression: a Filter, an And or an Or.
+type Expr interface {
+	isExpr()
+}
+
+// And matches the rows matching all of its expressions.
+type And []Expr
+
+// Or matches the rows matching any of its expressions.
+type Or []Expr
+
+func (Filter) isExpr() {}
+func (And) isExpr()    {}
+func (Or) isExpr()     {}
+
+// rsqlOperators maps the comparison operators of filter expressions to filter operators.
+var rsqlOperators = map[string]string{
+	"==":           "eq",
+	"!=":           "neq",
+	"=gt=":         "gt",
+	"=ge=":         "gte",
+	"=lt=":         "lt",
+	"=le=":         "lte",
+	"=in=":         "in",
+	"=out=":        "nin",
+	"=between=":    "between",
+	"=isnull=":     "isnull",
+	"=ilike=":      "ilike",
+	"=nilike=":     "nilike",
+	"=startswith=": "startswith",
+	"=contains=":   "contains",
+}
+
+// ParseExpr parses an RSQL filter expression on the fields of s, e.g.
+//
+//	(author_id==3,author_id==5);published_at=gt=946684800
+//
+// where ";" is AND, "," is OR, AND binds tighter than OR and parentheses group.
+// Comparisons are field, operator and argument with the operators ==, !=, =gt=,
+// =ge=, =lt=, =le=, =in=, =out=, =between=, =isnull=, =ilike=, =nilike=,
+// =startswith= and =contains=. The argument of =in=, =out= and =between= is a
+// parenthesized list, e.g. id=in=(1,2,3), and =isnull= takes true or false.
+// Values containing reserved characters are quoted with ' or ".
+func ParseExpr(s *Schema, raw string) (Expr, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	p := &rsqlParser{schema: s, input: raw}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos])
+	}
+	return expr, nil
+}
+
+// rsqlParser is a recursive descent parser of filter expressions.
+type rsqlParser struct {
+	schema *Schema
+	input  string
+	pos    int
+}
+
+func (p *rsqlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: q at position %d: %s", ErrInvalidQuery, p.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *rsqlParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// accept consumes c if it is the next character.
+func (p *rsqlParser) accept(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// parseOr parses and-expressions separated by ",".
+func (p *rsqlParser) parseOr() (Expr, error) {
+	var or Or
+	for {
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, expr)
+		if !p.accept(',') {
+			break
+		}
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+// parseAnd parses constraints separated by ";".
+func (p *rsqlParser) parseAnd() (Expr, error) {
+	var and And
+	for {
+		expr, err := p.parseConstraint()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, expr)
+		if !p.accept(';') {
+			break
+		}
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+// parseConstraint parses a parenthesized expression or a comparison.
+func (p *rsqlParser) parseConstraint() (Expr, error) {
+	if p.accept('(') {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(')') {
+			return nil, p.errorf("missing closing parenthesis")
+		}
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *rsqlParser) parseComparison() (Expr, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) && isSelectorChar(p.input[p.pos]) {
+		p.pos++
+	}
+	field := p.input[start:p.pos]
+	if field == "" {
+		return nil, p.errorf("expected a field")
+	}
+	if !p.schema.CanFilter(field) {
+		return nil, &UnknownValueError{Param: "q", Kind: "field", Value: field, Allowed: p.schema.FilterFields()}
+	}
+
+	op, err := p.parseOperator()
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	if op == "in" || op == "nin" || op == "between" {
+		if !p.accept('(') {
+			return nil, p.errorf("expected a parenthesized list of values")
+		}
+		for {
+			v, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+			if !p.accept(',') {
+				break
+			}
+		}
+		if !p.accept(')') {
+			return nil, p.errorf("missing closing parenthesis")
+		}
+	} else {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = []string{v}
+	}
+
+	if op == "isnull" {
+		switch values[0] {
+		case "true":
+		case "false":
+			op = "notnull"
+		default:
+			return nil, p.errorf("=isnull= takes true or false")
+		}
+		return Filter{Field: field, Operator: op}, nil
+	}
+	if op == "between" && len(values) != 2 {
+		return nil, p.errorf("=between= takes two values")
+	}
+	filter := Filter{Field: field, Operator: op, Value: values[0]}
+	if op == "in" || op == "nin" || op == "between" {
+		list := make([]interface{}, len(values))
+		for i, v := range values {
+			list[i] = v
+		}
+		filter.Value = list
+	}
+	filter, err = p.schema.coerceFilter(filter)
+	if err != nil {
+		var unknown *UnknownValueError
+		if errors.As(err, &unknown) {
+			unknown.Param = "q"
+		}
+		return nil, err
+	}
+	return filter, nil
+}
+
+// parseOperator parses == or an operator of the form =name= or !=.
+func (p *rsqlParser) parseOperator() (string, error) {
+	p.skipSpaces()
+	start := p.pos
+	switch {
+	case strings.HasPrefix(p.input[p.pos:], "=="), strings.HasPrefix(p.input[p.pos:], "!="):
+		p.pos += 2
+	case strings.HasPrefix(p.input[p.pos:], "="):
+		end := strings.IndexByte(p.input[p.pos+1:], '=')
+		if end < 0 {
+			return "", p.errorf("expected an operator")
+		}
+		p.pos += end + 2
+	default:
+		return "", p.errorf("expected an operator")
+	}
+
+	symbol := p.input[start:p.pos]
+	op, ok := rsqlOperators[symbol]
+	if !ok {
+		allowed := make([]string, 0, len(rsqlOperators))
+		for s := range rsqlOperators {
+			allowed = append(allowed, s)
+		}
+		sort.Strings(allowed)
+		return "", &UnknownValueError{Param: "q", Kind: "operator", Value: symbol, Allowed: allowed}
+	}
+	return op, nil
+}
+
+// parseValue parses a quoted string or a run of unreserved characters.
+func (p *rsqlParser) parseValue() (string, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return "", p.errorf("expected a value")
+	}
+
+	quote := p.input[p.pos]
+	if quote == '\'' || quote == '"' {
+		p.pos++
+		var b strings.Builder
+		for p.pos < len(p.input) {
+			c := p.input[p.pos]
+			p.pos++
+			switch {
+			case c == '\\' && p.pos < len(p.input):
+				b.WriteByte(p.input[p.pos])
+				p.pos++
+			case c == quote:
+				return b.String(), nil
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", p.errorf("unterminated string")
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && !isReserved(p.input[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", p.errorf("expected a value")
+	}
+	return p.input[start:p.pos], nil
+}
+
+func isSelectorChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isReserved(c byte) bool {
+	return strings.IndexByte(`"'();, =!~<>`, c) >= 0
+}
@@ -0,0 +1,95 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpr(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Expr
+	}{
+		{raw: "", want: nil},
+		{raw: "id==3", want: Filter{Field: "id", Operator: "eq", Value: int64(3)}},
+		{
+			raw: "(author_id==3,author_id==5);id=gt=10",
+			want: And{
+				Or{
+					Filter{Field: "author_id", Operator: "eq", Value: int64(3)},
+					Filter{Field: "author_id", Operator: "eq", Value: int64(5)},
+				},
+				Filter{Field: "id", Operator: "gt", Value: int64(10)},
+			},
+		},
+		{
+			// AND binds tighter than OR
+			raw: "id==1;title!=x,id==2",
+			want: Or{
+				And{
+					Filter{Field: "id", Operator: "eq", Value: int64(1)},
+					Filter{Field: "title", Operator: "neq", Value: "x"},
+				},
+				Filter{Field: "id", Operator: "eq", Value: int64(2)},
+			},
+		},
+		{
+			raw: `id=in=(1, 2) ; title=contains='a, b;(c)' ; id=between=(5,9)`,
+			want: And{
+				Filter{Field: "id", Operator: "in", Value: []interface{}{int64(1), int64(2)}},
+				Filter{Field: "title", Operator: "contains", Value: "a, b;(c)"},
+				Filter{Field: "id", Operator: "between", Value: []interface{}{int64(5), int64(9)}},
+			},
+		},
+		{raw: `title=="say \"hi\""`, want: Filter{Field: "title", Operator: "eq", Value: `say "hi"`}},
+		{raw: "title=isnull=false", want: Filter{Field: "title", Operator: "notnull"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseExpr(testSchema, tt.raw)
+		assert.NoError(t, err, tt.raw)
+		assert.Equal(t, tt.want, got, tt.raw)
+	}
+}
+
+func TestParseExprErrors(t *testing.T) {
+	for _, raw := range []string{
+		"id",
+		"id==",
+		"id==1;",
+		"(id==1",
+		"id==1)",
+		"id=in=1",
+		"id=between=(1)",
+		"title=='open",
+		"title=isnull=maybe",
+		"created_at=gt=tomorrow",
+	} {
+		_, err := ParseExpr(testSchema, raw)
+		assert.ErrorIs(t, err, ErrInvalidQuery, raw)
+	}
+
+	var unknown *UnknownValueError
+	_, err := ParseExpr(testSchema, "secret==1")
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "field", unknown.Kind)
+
+	_, err = ParseExpr(testSchema, "id=like=1")
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, "operator", unknown.Kind)
+}
+
+func TestBuildSelectQueryExpr(t *testing.T) {
+	where, err := ParseExpr(testSchema, "(author_id==3,author_id==5);id=gt=10")
+	assert.NoError(t, err)
+
+	q, args := BuildSelectQuery("test_items", QueryOptions{
+		Filters:     []Filter{{Field: "title", Operator: "ilike", Value: "%go%"}},
+		Where:       where,
+		FilterExprs: testSchema.FilterExprs(),
+	})
+	assert.Equal(t, "SELECT * FROM test_items WHERE title ILIKE $1 AND "+
+		"((id IN (SELECT item_id FROM item_authors WHERE author_id = $2) OR id IN (SELECT item_id FROM item_authors WHERE author_id = $3)) AND id > $4)", q)
+	assert.Equal(t, []interface{}{"%go%", int64(3), int64(5), int64(10)}, args)
+}
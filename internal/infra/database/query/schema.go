@@ -0,0 +1,186 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Schema lists what list requests on a table may select, filter and sort on.
+// Field names are only ever interpolated into SQL after being checked against it.
+type Schema struct {
+	Table       string
+	columns     []string
+	isColumn    map[string]bool
+	filterExprs map[string]string
+	types       map[string]FieldType
+	groupJoins  map[string]GroupJoin
+}
+
+// schemas is the registry of the schemas of every listed table, by table name.
+var schemas = make(map[string]*Schema)
+
+// Register derives the columns of a table and their types from the db tags of
+// model, a struct or a pointer to one, and adds the schema to the registry.
+// Fields tagged db:"-" are skipped. Integer columns named *_at hold Unix times.
+// filterExprs adds fields that can only be filtered on, see QueryOptions.FilterExprs,
+// whose values are strings unless typed with WithTypes.
+func Register(table string, model interface{}, filterExprs map[string]string) *Schema {
+	t := reflect.TypeOf(model)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("query: model of table %s is not a struct", table))
+	}
+
+	s := &Schema{
+		Table:       table,
+		isColumn:    make(map[string]bool),
+		filterExprs: filterExprs,
+		types:       make(map[string]FieldType),
+	}
+	for i := 0; i < t.NumField(); i++ {
+		name := columnName(t.Field(i))
+		if name == "" || s.isColumn[name] {
+			continue
+		}
+		s.columns = append(s.columns, name)
+		s.isColumn[name] = true
+		s.types[name] = fieldType(name, t.Field(i).Type)
+	}
+	schemas[table] = s
+	return s
+}
+
+// WithTypes sets the types of filter-only fields.
+func (s *Schema) WithTypes(types map[string]FieldType) *Schema {
+	for field, typ := range types {
+		s.types[field] = typ
+	}
+	return s
+}
+
+// WithGroupJoins adds fields that are not columns of the table to the fields
+// aggregates can be grouped by, see QueryOptions.GroupJoins.
+func (s *Schema) WithGroupJoins(joins map[string]GroupJoin) *Schema {
+	s.groupJoins = joins
+	return s
+}
+
+// GroupJoins returns the joins of the group-only fields.
+func (s *Schema) GroupJoins() map[string]GroupJoin {
+	return s.groupJoins
+}
+
+// Type returns the type of the values of a field.
+func (s *Schema) Type(field string) FieldType {
+	return s.types[field]
+}
+
+// fieldType maps the Go type of a model field to the type of its filter values.
+func fieldType(column string, t reflect.Type) FieldType {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if strings.HasSuffix(column, "_at") {
+			return TypeTime
+		}
+		return TypeInt
+	case reflect.Float32, reflect.Float64:
+		return TypeFloat
+	case reflect.Bool:
+		return TypeBool
+	}
+	return TypeString
+}
+
+// Lookup returns the registered schema of a table, or nil when there is none.
+func Lookup(table string) *Schema {
+	return schemas[table]
+}
+
+// Columns returns the columns of the table in the order of the model fields.
+func (s *Schema) Columns() []string {
+	return append([]string(nil), s.columns...)
+}
+
+// FilterExprs returns the SQL conditions of the filter-only fields.
+func (s *Schema) FilterExprs() map[string]string {
+	return s.filterExprs
+}
+
+// HasColumn reports whether name is a column of the table.
+func (s *Schema) HasColumn(name string) bool {
+	return s.isColumn[name]
+}
+
+// CanFilter reports whether the table can be filtered on the field.
+func (s *Schema) CanFilter(field string) bool {
+	_, ok := s.filterExprs[field]
+	return ok || s.isColumn[field]
+}
+
+// FilterFields returns the fields the table can be filtered on, sorted.
+func (s *Schema) FilterFields() []string {
+	fields := s.Columns()
+	for f := range s.filterExprs {
+		if !s.isColumn[f] {
+			fields = append(fields, f)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// SortedColumns returns the columns of the table, sorted.
+func (s *Schema) SortedColumns() []string {
+	fields := s.Columns()
+	sort.Strings(fields)
+	return fields
+}
+
+// GroupFields returns the fields aggregates can be grouped by, sorted.
+func (s *Schema) GroupFields() []string {
+	fields := s.Columns()
+	for f := range s.groupJoins {
+		if !s.isColumn[f] {
+			fields = append(fields, f)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// CanAggregate reports whether the aggregate function applies to the column.
+// Columns of numbers can be summed, except for ids, and columns of numbers,
+// times and strings have a minimum and maximum.
+func (s *Schema) CanAggregate(fn, column string) bool {
+	if !s.isColumn[column] {
+		return false
+	}
+	typ := s.types[column]
+	switch fn {
+	case "sum":
+		isID := column == "id" || strings.HasSuffix(column, "_id")
+		return (typ == TypeInt || typ == TypeFloat) && !isID
+	case "min", "max":
+		return typ != TypeBool
+	}
+	return false
+}
+
+// AggregateFields returns the columns the aggregate function applies to, sorted.
+func (s *Schema) AggregateFields(fn string) []string {
+	var fields []string
+	for _, c := range s.SortedColumns() {
+		if s.CanAggregate(fn, c) {
+			fields = append(fields, c)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,8 @@
+package storage
+
+import "github.com/google/wire"
+
+// ProviderSetStorage is providers.
+var ProviderSetStorage = wire.NewSet(
+	NewStorage,
+)
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"borrow_book/internal/config"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned when no object is stored under a key.
+var ErrNotFound = errors.New("object not found")
+
+// Storage stores files under slash separated keys, e.g. "covers/42/original.jpg".
+type Storage interface {
+	// Put stores the content of r under key, replacing any previous object.
+	Put(ctx context.Context, key, contentType string, r io.Reader) error
+	// Get opens the object stored under key. The caller must close its Body.
+	Get(ctx context.Context, key string) (*Object, error)
+	// Delete removes the object stored under key. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Object is a stored file opened for reading.
+type Object struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
+
+// NewStorage creates the storage selected by the configured driver.
+func NewStorage(cfg *config.Config) (Storage, error) {
+	switch cfg.Storage.Driver {
+	case "", "local":
+		return NewLocalStorage(cfg.Storage.LocalDir)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+}
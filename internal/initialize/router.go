@@ -1,14 +1,15 @@
 package initialize
 
 import (
+	"borrow_book/internal/config"
 	"borrow_book/internal/router"
 
 	"github.com/jmoiron/sqlx"
 )
 
 // InitRouter sets up the application router using dependency injection.
-func InitAppRouter(pgDB *sqlx.DB) (*router.AppRouter, error) {
-	appRouter, err := InitializeApp(pgDB)
+func InitAppRouter(pgDB *sqlx.DB, cfg *config.Config) (*router.AppRouter, error) {
+	appRouter, err := InitializeApp(pgDB, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +33,7 @@ func Run() {
 	// }
 
 	// Initialize Router
-	appRouter, err := InitAppRouter(dbConn)
+	appRouter, err := InitAppRouter(dbConn, cfg)
 	if err != nil {
 		appLogger.Errorf("AppRouter initialization failed: %v", err)
 		os.Exit(1)
@@ -39,7 +39,18 @@ func InitServer(config *config.Config, appRouter *router.AppRouter) *http.Server
 func registerAPIRoutes(group *gin.RouterGroup, appRouter *router.AppRouter) {
 	appRouter.RegisterBookRoutes(group)
 	appRouter.RegisterAuthorRoutes(group)
+	appRouter.RegisterPublisherRoutes(group)
+	appRouter.RegisterGenreRoutes(group)
+	appRouter.RegisterTagRoutes(group)
+	appRouter.RegisterMemberRoutes(group)
+	appRouter.RegisterLoanPolicyRoutes(group)
 	appRouter.RegisterBorrowRoutes(group)
+	appRouter.RegisterCopyRoutes(group)
+	appRouter.RegisterBranchRoutes(group)
+	appRouter.RegisterTransferRoutes(group)
+	appRouter.RegisterSearchRoutes(group)
+	appRouter.RegisterReservationRoutes(group)
+	appRouter.RegisterFineRoutes(group)
 }
 
 func registerSwaggerRoutes(router *gin.Engine, appRouter *router.AppRouter) {
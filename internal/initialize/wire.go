@@ -5,7 +5,10 @@
 package initialize
 
 import (
+	"borrow_book/internal/config"
 	"borrow_book/internal/handler"
+	"borrow_book/internal/infra/database/postgres"
+	"borrow_book/internal/infra/storage"
 	"borrow_book/internal/repository"
 	"borrow_book/internal/router"
 	"borrow_book/internal/service"
@@ -14,12 +17,14 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-func InitializeApp(db *sqlx.DB) (*router.AppRouter, error) {
+func InitializeApp(db *sqlx.DB, cfg *config.Config) (*router.AppRouter, error) {
 	wire.Build(
 		handler.ProviderSetHandler,
 		service.ProviderSetService,
 		repository.ProviderSetRepository,
 		router.ProviderSetRouter,
+		storage.ProviderSetStorage,
+		postgres.ProviderSetPostgres,
 	)
 	return &router.AppRouter{}, nil
 }
@@ -7,7 +7,10 @@
 package initialize
 
 import (
+	"borrow_book/internal/config"
 	"borrow_book/internal/handler"
+	"borrow_book/internal/infra/database/postgres"
+	"borrow_book/internal/infra/storage"
 	"borrow_book/internal/repository"
 	"borrow_book/internal/router"
 	"borrow_book/internal/service"
@@ -16,17 +19,59 @@ import (
 
 // Injectors from wire.go:
 
-func InitializeApp(db *sqlx.DB) (*router.AppRouter, error) {
+func InitializeApp(db *sqlx.DB, cfg *config.Config) (*router.AppRouter, error) {
 	bookRepository := repository.NewBookRepository(db)
-	bookService := service.NewBookService(bookRepository)
-	bookHandler := handler.NewBookHandler(bookService)
 	authorRepository := repository.NewAuthorRepository(db)
-	authorService := service.NewAuthorService(authorRepository)
+	publisherRepository := repository.NewPublisherRepository(db)
+	bookService := service.NewBookService(bookRepository, authorRepository, publisherRepository, cfg)
+	bookHandler := handler.NewBookHandler(bookService)
+	authorService := service.NewAuthorService(authorRepository, cfg)
 	authorHandler := handler.NewAuthorHandler(authorService)
+	publisherService := service.NewPublisherService(publisherRepository)
+	publisherHandler := handler.NewPublisherHandler(publisherService)
 	borrowRepository := repository.NewBorrowRepository(db)
-	borrowService := service.NewBorrowService(borrowRepository)
-	borrowHandler := handler.NewBorrowHandler(borrowService)
+	memberRepository := repository.NewMemberRepository(db)
+	bookCopyRepository := repository.NewBookCopyRepository(db)
+	borrowRenewalRepository := repository.NewBorrowRenewalRepository(db)
+	reservationRepository := repository.NewReservationRepository(db)
+	memberService := service.NewMemberService(memberRepository)
+	txManager := postgres.NewTxManager(db)
+	reservationService := service.NewReservationService(reservationRepository, bookRepository, bookCopyRepository, memberService, txManager, cfg)
+	fineRepository := repository.NewFineRepository(db)
+	fineService := service.NewFineService(fineRepository, memberRepository, txManager, cfg)
+	loanPolicyRepository := repository.NewLoanPolicyRepository(db)
+	loanPolicyService := service.NewLoanPolicyService(loanPolicyRepository, borrowRepository, cfg)
+	branchRepository := repository.NewBranchRepository(db)
+	branchService := service.NewBranchService(branchRepository)
+	borrowService := service.NewBorrowService(borrowRepository, bookRepository, memberRepository, bookService, bookCopyRepository, borrowRenewalRepository, reservationService, fineService, memberService, loanPolicyService, branchService, txManager, cfg)
+	borrowHandler := handler.NewBorrowHandler(borrowService, cfg)
+	bookCopyService := service.NewBookCopyService(bookCopyRepository, bookRepository, reservationService, branchService)
+	bookCopyHandler := handler.NewBookCopyHandler(bookCopyService)
+	reservationHandler := handler.NewReservationHandler(reservationService)
+	fineHandler := handler.NewFineHandler(fineService)
+	memberHandler := handler.NewMemberHandler(memberService)
+	loanPolicyHandler := handler.NewLoanPolicyHandler(loanPolicyService)
+	genreRepository := repository.NewGenreRepository(db)
+	genreService := service.NewGenreService(genreRepository, bookRepository)
+	genreHandler := handler.NewGenreHandler(genreService)
+	tagRepository := repository.NewTagRepository(db)
+	tagService := service.NewTagService(tagRepository, bookRepository)
+	tagHandler := handler.NewTagHandler(tagService)
+	branchHandler := handler.NewBranchHandler(branchService)
+	transferRepository := repository.NewTransferRepository(db)
+	transferService := service.NewTransferService(transferRepository, bookCopyRepository, branchService, reservationService, txManager)
+	transferHandler := handler.NewTransferHandler(transferService)
+	bookCoverRepository := repository.NewBookCoverRepository(db)
+	storageStorage, err := storage.NewStorage(cfg)
+	if err != nil {
+		return nil, err
+	}
+	bookCoverService := service.NewBookCoverService(bookCoverRepository, bookRepository, storageStorage, cfg)
+	bookCoverHandler := handler.NewBookCoverHandler(bookCoverService, cfg)
+	searchRepository := repository.NewSearchRepository(db)
+	searchService := service.NewSearchService(searchRepository, cfg)
+	searchHandler := handler.NewSearchHandler(searchService)
 	swaggerRouter := router.NewSwaggerRouter()
-	appRouter := router.NewAppRouter(bookHandler, authorHandler, borrowHandler, swaggerRouter)
+	appRouter := router.NewAppRouter(bookHandler, authorHandler, publisherHandler, borrowHandler, bookCopyHandler, reservationHandler, fineHandler, memberHandler, loanPolicyHandler, genreHandler, tagHandler, branchHandler, transferHandler, bookCoverHandler, searchHandler, swaggerRouter)
 	return appRouter, nil
 }
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// selectAggregates runs an aggregate query and returns its rows by column name.
+// Text the driver hands over as bytes comes back as strings.
+func selectAggregates(ctx context.Context, db *sqlx.DB, q string, args ...interface{}) ([]map[string]interface{}, error) {
+	rows, err := conn(ctx, db).QueryxContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []map[string]interface{}{}
+	for rows.Next() {
+		row := make(map[string]interface{})
+		if err := rows.MapScan(row); err != nil {
+			return nil, err
+		}
+		for k, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[k] = string(b)
+			}
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
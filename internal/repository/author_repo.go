@@ -10,13 +10,19 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// AuthorSchema lists the columns authors can be listed by.
+var AuthorSchema = query.Register("authors", model.Author{}, nil)
+
 // AuthorRepository defines the interface for author-related data operations
 type AuthorRepository interface {
 	GetAllAuthors(ctx context.Context, opts query.QueryOptions) ([]model.Author, error)
+	CountAuthors(ctx context.Context, opts query.QueryOptions) (int, error)
 	GetAuthorByID(ctx context.Context, id int) (*model.Author, error)
+	GetAuthorsByIDs(ctx context.Context, ids []int) ([]model.Author, error)
 	GetAuthorByName(ctx context.Context, name string) (*model.Author, error)
 	CreateAuthor(ctx context.Context, a model.Author) (int, error)
-	UpdateAuthor(ctx context.Context, a model.Author) error
+	// UpdateAuthor writes the columns of an author that changed from old.
+	UpdateAuthor(ctx context.Context, old, a model.Author) error
 	DeleteAuthor(ctx context.Context, id int) error
 }
 
@@ -31,24 +37,40 @@ func NewAuthorRepository(db *sqlx.DB) AuthorRepository {
 }
 
 func (r *authorRepository) GetAllAuthors(ctx context.Context, opts query.QueryOptions) ([]model.Author, error) {
-	q, args := query.BuildSelectQuery("authors", opts)
+	if len(opts.Fields) == 0 {
+		opts.Fields = AuthorSchema.Columns() // Leave out the search vector
+	}
+	q, args := query.BuildSelectQuery(AuthorSchema.Table, opts)
 	var authors []model.Author
-	err := r.db.SelectContext(ctx, &authors, q, args...)
+	err := conn(ctx, r.db).SelectContext(ctx, &authors, q, args...)
 	return authors, err
 }
 
+func (r *authorRepository) CountAuthors(ctx context.Context, opts query.QueryOptions) (int, error) {
+	q, args := query.BuildCountQuery(AuthorSchema.Table, opts)
+	var count int
+	err := conn(ctx, r.db).GetContext(ctx, &count, q, args...)
+	return count, err
+}
+
 func (r *authorRepository) GetAuthorByID(ctx context.Context, id int) (*model.Author, error) {
 	var author model.Author
-	err := r.db.GetContext(ctx, &author, "SELECT id, name FROM authors WHERE id=$1", id)
+	err := conn(ctx, r.db).GetContext(ctx, &author, "SELECT id, name FROM authors WHERE id=$1", id)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	return &author, err
 }
 
+func (r *authorRepository) GetAuthorsByIDs(ctx context.Context, ids []int) ([]model.Author, error) {
+	var authors []model.Author
+	err := conn(ctx, r.db).SelectContext(ctx, &authors, "SELECT id, name FROM authors WHERE id = ANY($1)", idArray(ids))
+	return authors, err
+}
+
 func (r *authorRepository) GetAuthorByName(ctx context.Context, name string) (*model.Author, error) {
 	var author model.Author
-	err := r.db.GetContext(ctx, &author, "SELECT id, name FROM authors WHERE name=$1", name)
+	err := conn(ctx, r.db).GetContext(ctx, &author, "SELECT id, name FROM authors WHERE name=$1", name)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -57,29 +79,31 @@ func (r *authorRepository) GetAuthorByName(ctx context.Context, name string) (*m
 
 func (r *authorRepository) CreateAuthor(ctx context.Context, a model.Author) (int, error) {
 	var id int
-	err := r.db.QueryRowContext(ctx,
-		"INSERT INTO authors (name) VALUES ($1) RETURNING id",
-		a.Name,
-	).Scan(&id)
+	q, args := query.BuildInsertQuery(AuthorSchema.Table, query.Assignments(a, "id"), "id")
+	err := conn(ctx, r.db).QueryRowContext(ctx, q, args...).Scan(&id)
 	return id, err
 }
 
-func (r *authorRepository) UpdateAuthor(ctx context.Context, a model.Author) error {
-	res, err := r.db.ExecContext(ctx,
-		"UPDATE authors SET name=$1 WHERE id=$2",
-		a.Name, a.ID)
+func (r *authorRepository) UpdateAuthor(ctx context.Context, old, a model.Author) error {
+	changes := query.Changes(old, a, "id")
+	if len(changes) == 0 {
+		return nil
+	}
+	q, args := query.BuildUpdateQuery(AuthorSchema.Table, changes, []query.Filter{{Field: "id", Operator: "eq", Value: a.ID}})
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
 	if err != nil {
 		return err
 	}
 	rows, _ := res.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("no rows updated")
+		return ErrNoRowsUpdated
 	}
 	return nil
 }
 
 func (r *authorRepository) DeleteAuthor(ctx context.Context, id int) error {
-	res, err := r.db.ExecContext(ctx, "DELETE FROM authors WHERE id=$1", id)
+	q, args := query.BuildDeleteQuery(AuthorSchema.Table, []query.Filter{{Field: "id", Operator: "eq", Value: id}})
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BookCopyRepository defines the interface for physical copy data operations
+type BookCopyRepository interface {
+	GetCopiesByBookID(ctx context.Context, bookID int) ([]model.BookCopy, error)
+	GetCopyByID(ctx context.Context, id int) (*model.BookCopy, error)
+	GetCopyByBarcode(ctx context.Context, barcode string) (*model.BookCopy, error)
+	// GetAvailability counts the copies of a book at a branch, or at every branch when branchID is 0.
+	GetAvailability(ctx context.Context, bookID, branchID int) (*model.BookAvailability, error)
+	CreateCopy(ctx context.Context, c model.BookCopy) (int, error)
+	UpdateCopy(ctx context.Context, c model.BookCopy) error
+	UpdateCopyStatus(ctx context.Context, id int, status string) error
+	TransitionCopyStatus(ctx context.Context, id int, from, to string) (*model.BookCopy, error)
+	MoveCopy(ctx context.Context, id, branchID int) error
+	ClaimCopy(ctx context.Context, id int, status string) (*model.BookCopy, error)
+	ClaimAvailableCopy(ctx context.Context, bookID, branchID int) (*model.BookCopy, error)
+	DeleteCopy(ctx context.Context, id int) error
+}
+
+// bookCopyRepository is the concrete implementation of BookCopyRepository
+type bookCopyRepository struct {
+	db *sqlx.DB
+}
+
+// NewBookCopyRepository creates a new instance of BookCopyRepository
+func NewBookCopyRepository(db *sqlx.DB) BookCopyRepository {
+	return &bookCopyRepository{db: db}
+}
+
+func (r *bookCopyRepository) GetCopiesByBookID(ctx context.Context, bookID int) ([]model.BookCopy, error) {
+	var copies []model.BookCopy
+	err := conn(ctx, r.db).SelectContext(ctx, &copies,
+		"SELECT id, book_id, branch_id, barcode, status, acquired_at FROM book_copies WHERE book_id=$1 ORDER BY id", bookID)
+	return copies, err
+}
+
+func (r *bookCopyRepository) GetCopyByID(ctx context.Context, id int) (*model.BookCopy, error) {
+	var c model.BookCopy
+	err := conn(ctx, r.db).GetContext(ctx, &c, "SELECT id, book_id, branch_id, barcode, status, acquired_at FROM book_copies WHERE id=$1", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &c, err
+}
+
+func (r *bookCopyRepository) GetCopyByBarcode(ctx context.Context, barcode string) (*model.BookCopy, error) {
+	var c model.BookCopy
+	err := conn(ctx, r.db).GetContext(ctx, &c, "SELECT id, book_id, branch_id, barcode, status, acquired_at FROM book_copies WHERE barcode=$1", barcode)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &c, err
+}
+
+func (r *bookCopyRepository) GetAvailability(ctx context.Context, bookID, branchID int) (*model.BookAvailability, error) {
+	availability := model.BookAvailability{BookID: bookID}
+	err := conn(ctx, r.db).GetContext(ctx, &availability, `
+		SELECT $1::INTEGER AS book_id,
+			NULLIF($2::INTEGER, 0) AS branch_id,
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE status = $3) AS available,
+			COUNT(*) FILTER (WHERE status = $4) AS on_loan,
+			COUNT(*) FILTER (WHERE status = $5) AS on_hold,
+			COUNT(*) FILTER (WHERE status = $6) AS in_transit
+		FROM book_copies
+		WHERE book_id = $1 AND ($2 = 0 OR branch_id = $2)`,
+		bookID, branchID, model.CopyStatusAvailable, model.CopyStatusOnLoan, model.CopyStatusOnHold, model.CopyStatusInTransit)
+	return &availability, err
+}
+
+func (r *bookCopyRepository) CreateCopy(ctx context.Context, c model.BookCopy) (int, error) {
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"INSERT INTO book_copies (book_id, branch_id, barcode, status, acquired_at) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+		c.BookID, c.BranchID, c.Barcode, c.Status, c.AcquiredAt,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *bookCopyRepository) UpdateCopy(ctx context.Context, c model.BookCopy) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE book_copies SET barcode=$1, status=$2, acquired_at=$3 WHERE id=$4",
+		c.Barcode, c.Status, c.AcquiredAt, c.ID)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+func (r *bookCopyRepository) UpdateCopyStatus(ctx context.Context, id int, status string) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE book_copies SET status=$1 WHERE id=$2", status, id)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+// TransitionCopyStatus moves the given copy from one status to another.
+// It returns nil when the copy does not exist or is not in the from status.
+func (r *bookCopyRepository) TransitionCopyStatus(ctx context.Context, id int, from, to string) (*model.BookCopy, error) {
+	var c model.BookCopy
+	err := conn(ctx, r.db).GetContext(ctx, &c, `
+		UPDATE book_copies SET status=$1
+		WHERE id=$2 AND status=$3
+		RETURNING id, book_id, branch_id, barcode, status, acquired_at`,
+		to, id, from)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &c, err
+}
+
+// MoveCopy records that the copy is now at the given branch.
+func (r *bookCopyRepository) MoveCopy(ctx context.Context, id, branchID int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE book_copies SET branch_id=$1 WHERE id=$2", branchID, id)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+// ClaimCopy marks the given copy as on loan if it currently has the given status.
+// It returns nil when the copy does not exist or has another status.
+func (r *bookCopyRepository) ClaimCopy(ctx context.Context, id int, status string) (*model.BookCopy, error) {
+	return r.TransitionCopyStatus(ctx, id, status, model.CopyStatusOnLoan)
+}
+
+// ClaimAvailableCopy marks the first available copy of a book as on loan, only
+// considering the copies at the given branch unless branchID is 0.
+// It returns nil when no copy of the book is available.
+func (r *bookCopyRepository) ClaimAvailableCopy(ctx context.Context, bookID, branchID int) (*model.BookCopy, error) {
+	var c model.BookCopy
+	err := conn(ctx, r.db).GetContext(ctx, &c, `
+		UPDATE book_copies SET status=$1
+		WHERE id = (
+			SELECT id FROM book_copies
+			WHERE book_id=$2 AND status=$3 AND ($4 = 0 OR branch_id = $4)
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, book_id, branch_id, barcode, status, acquired_at`,
+		model.CopyStatusOnLoan, bookID, model.CopyStatusAvailable, branchID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &c, err
+}
+
+func (r *bookCopyRepository) DeleteCopy(ctx context.Context, id int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM book_copies WHERE id=$1", id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrReferenced
+		}
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("no rows deleted")
+	}
+	return nil
+}
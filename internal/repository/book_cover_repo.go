@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BookCoverRepository defines the interface for book cover data operations
+type BookCoverRepository interface {
+	GetCoverByBookID(ctx context.Context, bookID int) (*model.BookCover, error)
+	// SaveCover creates the cover of a book or replaces the existing one.
+	SaveCover(ctx context.Context, c model.BookCover) error
+}
+
+// bookCoverRepository is the concrete implementation of BookCoverRepository
+type bookCoverRepository struct {
+	db *sqlx.DB
+}
+
+// NewBookCoverRepository creates a new instance of BookCoverRepository
+func NewBookCoverRepository(db *sqlx.DB) BookCoverRepository {
+	return &bookCoverRepository{db: db}
+}
+
+func (r *bookCoverRepository) GetCoverByBookID(ctx context.Context, bookID int) (*model.BookCover, error) {
+	var c model.BookCover
+	err := conn(ctx, r.db).GetContext(ctx, &c, `
+		SELECT book_id, content_type, width, height, size, checksum, updated_at
+		FROM book_covers WHERE book_id=$1`, bookID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &c, err
+}
+
+func (r *bookCoverRepository) SaveCover(ctx context.Context, c model.BookCover) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO book_covers (book_id, content_type, width, height, size, checksum, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (book_id) DO UPDATE SET
+			content_type = EXCLUDED.content_type,
+			width = EXCLUDED.width,
+			height = EXCLUDED.height,
+			size = EXCLUDED.size,
+			checksum = EXCLUDED.checksum,
+			updated_at = EXCLUDED.updated_at`,
+		c.BookID, c.ContentType, c.Width, c.Height, c.Size, c.Checksum, c.UpdatedAt)
+	return err
+}
@@ -2,20 +2,27 @@ package repository
 
 import (
 	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/postgres"
 	"borrow_book/internal/infra/database/query"
 	"context"
 	"database/sql"
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type BookRepository interface {
 	GetAllBooks(ctx context.Context, opts query.QueryOptions) ([]model.Book, error)
+	CountBooks(ctx context.Context, opts query.QueryOptions) (int, error)
+	AggregateBooks(ctx context.Context, opts query.QueryOptions) ([]map[string]interface{}, error)
 	GetBookByID(ctx context.Context, id int) (*model.Book, error)
+	GetBooksByIDs(ctx context.Context, ids []int) ([]model.Book, error)
 	GetBookByTitleAndAuthorID(ctx context.Context, title string, authorID int) (*model.Book, error)
+	GetBookByISBN(ctx context.Context, isbn13 string) (*model.Book, error)
 	CreateBook(ctx context.Context, b model.Book) (int, error)
-	UpdateBook(ctx context.Context, b model.Book) error
+	// UpdateBook writes the columns of a book that changed from old and replaces its contributors.
+	UpdateBook(ctx context.Context, old, b model.Book) error
 	DeleteBook(ctx context.Context, id int) error
 }
 
@@ -27,62 +34,266 @@ func NewBookRepository(db *sqlx.DB) BookRepository {
 	return &bookRepository{db: db}
 }
 
+// bookFilterExprs lets books be filtered on their contributors, genres and tags.
+// A genre filter matches the books of the genre and of all its descendants.
+var bookFilterExprs = map[string]string{
+	"author_id": "id IN (SELECT book_id FROM book_authors WHERE author_id %s)",
+	"genre_id": `id IN (
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM genres WHERE id %s
+			UNION
+			SELECT g.id FROM genres g JOIN subtree s ON g.parent_id = s.id
+		)
+		SELECT bg.book_id FROM book_genres bg JOIN subtree s ON s.id = bg.genre_id)`,
+	"tag": "id IN (SELECT bt.book_id FROM book_tags bt JOIN tags t ON t.id = bt.tag_id WHERE t.name %s)",
+}
+
+// bookGroupJoins lets book aggregates be grouped by contributor, genre and tag.
+// A book counts once in each of its groups, and books with none are grouped under null.
+var bookGroupJoins = map[string]query.GroupJoin{
+	"author_id": {Join: "LEFT JOIN book_authors ba ON ba.book_id = t.id", Column: "ba.author_id"},
+	"genre_id":  {Join: "LEFT JOIN book_genres bg ON bg.book_id = t.id", Column: "bg.genre_id"},
+	"tag": {
+		Join:   "LEFT JOIN (SELECT bt.book_id, tg.name FROM book_tags bt JOIN tags tg ON tg.id = bt.tag_id) bt ON bt.book_id = t.id",
+		Column: "bt.name",
+	},
+}
+
+// BookSchema lists what books can be listed by, including the filters above.
+var BookSchema = query.Register("books", model.Book{}, bookFilterExprs).WithTypes(map[string]query.FieldType{
+	"author_id": query.TypeInt,
+	"genre_id":  query.TypeInt,
+}).WithGroupJoins(bookGroupJoins)
+
 func (r *bookRepository) GetAllBooks(ctx context.Context, opts query.QueryOptions) ([]model.Book, error) {
-	q, args := query.BuildSelectQuery("books", opts)
+	opts.FilterExprs = BookSchema.FilterExprs()
+	if len(opts.Fields) == 0 {
+		opts.Fields = BookSchema.Columns() // Leave out the search vector
+	}
+	q, args := query.BuildSelectQuery(BookSchema.Table, opts)
 	var books []model.Book
-	err := r.db.SelectContext(ctx, &books, q, args...)
+	err := conn(ctx, r.db).SelectContext(ctx, &books, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	err = r.loadRelations(ctx, books)
 	return books, err
 }
 
+func (r *bookRepository) CountBooks(ctx context.Context, opts query.QueryOptions) (int, error) {
+	opts.FilterExprs = BookSchema.FilterExprs()
+	q, args := query.BuildCountQuery(BookSchema.Table, opts)
+	var count int
+	err := conn(ctx, r.db).GetContext(ctx, &count, q, args...)
+	return count, err
+}
+
+func (r *bookRepository) AggregateBooks(ctx context.Context, opts query.QueryOptions) ([]map[string]interface{}, error) {
+	opts.FilterExprs = BookSchema.FilterExprs()
+	opts.GroupJoins = BookSchema.GroupJoins()
+	q, args := query.BuildAggregateQuery(BookSchema.Table, opts)
+	return selectAggregates(ctx, r.db, q, args...)
+}
+
 func (r *bookRepository) GetBookByID(ctx context.Context, id int) (*model.Book, error) {
 	var book model.Book
-	err := r.db.GetContext(ctx, &book, "SELECT id, title, author_id, published_at FROM books WHERE id=$1", id)
+	err := conn(ctx, r.db).GetContext(ctx, &book, "SELECT id, title, isbn13, isbn10, category, published_at, publisher_id, edition, page_count, language, format FROM books WHERE id=$1", id)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
-	return &book, err
+	if err != nil {
+		return nil, err
+	}
+	return r.withContributors(ctx, book)
+}
+
+func (r *bookRepository) GetBooksByIDs(ctx context.Context, ids []int) ([]model.Book, error) {
+	var books []model.Book
+	err := conn(ctx, r.db).SelectContext(ctx, &books, "SELECT id, title, isbn13, isbn10, category, published_at, publisher_id, edition, page_count, language, format FROM books WHERE id = ANY($1)", idArray(ids))
+	if err != nil {
+		return nil, err
+	}
+	err = r.loadRelations(ctx, books)
+	return books, err
 }
 
 func (r *bookRepository) GetBookByTitleAndAuthorID(ctx context.Context, title string, authorID int) (*model.Book, error) {
 	var book model.Book
-	err := r.db.GetContext(ctx, &book, "SELECT id, title, author_id, published_at FROM books WHERE title=$1 AND author_id=$2", title, authorID)
+	err := conn(ctx, r.db).GetContext(ctx, &book, `
+		SELECT b.id, b.title, b.isbn13, b.isbn10, b.category, b.published_at,
+			b.publisher_id, b.edition, b.page_count, b.language, b.format
+		FROM books b
+		JOIN book_authors ba ON ba.book_id = b.id
+		WHERE b.title=$1 AND ba.author_id=$2
+		LIMIT 1`, title, authorID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.withContributors(ctx, book)
+}
+
+func (r *bookRepository) GetBookByISBN(ctx context.Context, isbn13 string) (*model.Book, error) {
+	var book model.Book
+	err := conn(ctx, r.db).GetContext(ctx, &book, "SELECT id, title, isbn13, isbn10, category, published_at, publisher_id, edition, page_count, language, format FROM books WHERE isbn13=$1", isbn13)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
-	return &book, err
+	if err != nil {
+		return nil, err
+	}
+	return r.withContributors(ctx, book)
 }
 
+// CreateBook inserts the book and its contributors in a single transaction.
 func (r *bookRepository) CreateBook(ctx context.Context, b model.Book) (int, error) {
 	var id int
-	err := r.db.QueryRowContext(ctx,
-		"INSERT INTO books (title, author_id, published_at) VALUES ($1, $2, $3) RETURNING id",
-		b.Title, b.AuthorID, b.PublishedAt,
-	).Scan(&id)
+	err := postgres.WithinTx(ctx, r.db, func(ctx context.Context) error {
+		q, args := query.BuildInsertQuery(BookSchema.Table, query.Assignments(b, "id"), "id")
+		if err := conn(ctx, r.db).QueryRowContext(ctx, q, args...).Scan(&id); err != nil {
+			return err
+		}
+		return insertContributors(ctx, conn(ctx, r.db), id, b.Contributors)
+	})
 	return id, err
 }
 
-func (r *bookRepository) UpdateBook(ctx context.Context, b model.Book) error {
-	res, err := r.db.ExecContext(ctx,
-		"UPDATE books SET title=$1, author_id=$2, published_at=$3 WHERE id=$4",
-		b.Title, b.AuthorID, b.PublishedAt, b.ID)
+// UpdateBook updates the book and replaces its contributors in a single transaction.
+func (r *bookRepository) UpdateBook(ctx context.Context, old, b model.Book) error {
+	return postgres.WithinTx(ctx, r.db, func(ctx context.Context) error {
+		byID := []query.Filter{{Field: "id", Operator: "eq", Value: b.ID}}
+		if changes := query.Changes(old, b, "id"); len(changes) > 0 {
+			q, args := query.BuildUpdateQuery(BookSchema.Table, changes, byID)
+			res, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
+			if err != nil {
+				return err
+			}
+			rows, _ := res.RowsAffected()
+			if rows == 0 {
+				return ErrNoRowsUpdated
+			}
+		}
+
+		q, args := query.BuildDeleteQuery("book_authors", []query.Filter{{Field: "book_id", Operator: "eq", Value: b.ID}})
+		if _, err := conn(ctx, r.db).ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+		return insertContributors(ctx, conn(ctx, r.db), b.ID, b.Contributors)
+	})
+}
+
+func (r *bookRepository) DeleteBook(ctx context.Context, id int) error {
+	q, args := query.BuildDeleteQuery(BookSchema.Table, []query.Filter{{Field: "id", Operator: "eq", Value: id}})
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
 	if err != nil {
 		return err
 	}
 	rows, _ := res.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("no rows updated")
+		return fmt.Errorf("no rows deleted")
 	}
 	return nil
 }
 
-func (r *bookRepository) DeleteBook(ctx context.Context, id int) error {
-	res, err := r.db.ExecContext(ctx, "DELETE FROM books WHERE id=$1", id)
+func (r *bookRepository) withContributors(ctx context.Context, book model.Book) (*model.Book, error) {
+	books := []model.Book{book}
+	if err := r.loadRelations(ctx, books); err != nil {
+		return nil, err
+	}
+	return &books[0], nil
+}
+
+// loadRelations fills the contributors, genres and tags of the books with a single query each.
+func (r *bookRepository) loadRelations(ctx context.Context, books []model.Book) error {
+	if len(books) == 0 {
+		return nil
+	}
+	bookIDs := make([]int, len(books))
+	for i, b := range books {
+		bookIDs[i] = b.ID
+	}
+	ids := idArray(bookIDs)
+
+	var contributors []model.BookContributor
+	err := conn(ctx, r.db).SelectContext(ctx, &contributors, `
+		SELECT ba.book_id, ba.author_id, a.name, ba.role, ba.position
+		FROM book_authors ba
+		JOIN authors a ON a.id = ba.author_id
+		WHERE ba.book_id = ANY($1)
+		ORDER BY ba.book_id, ba.position`, ids)
 	if err != nil {
 		return err
 	}
-	rows, _ := res.RowsAffected()
-	if rows == 0 {
-		return fmt.Errorf("no rows deleted")
+
+	var genres []struct {
+		BookID int `db:"book_id"`
+		model.Genre
+	}
+	err = conn(ctx, r.db).SelectContext(ctx, &genres, `
+		SELECT bg.book_id, g.id, g.name, g.parent_id
+		FROM book_genres bg
+		JOIN genres g ON g.id = bg.genre_id
+		WHERE bg.book_id = ANY($1)
+		ORDER BY g.name`, ids)
+	if err != nil {
+		return err
+	}
+
+	var tags []struct {
+		BookID int `db:"book_id"`
+		model.Tag
+	}
+	err = conn(ctx, r.db).SelectContext(ctx, &tags, `
+		SELECT bt.book_id, t.id, t.name
+		FROM book_tags bt
+		JOIN tags t ON t.id = bt.tag_id
+		WHERE bt.book_id = ANY($1)
+		ORDER BY t.name`, ids)
+	if err != nil {
+		return err
+	}
+
+	index := make(map[int]*model.Book, len(books))
+	for i := range books {
+		index[books[i].ID] = &books[i]
+	}
+	for _, c := range contributors {
+		if b, ok := index[c.BookID]; ok {
+			b.Contributors = append(b.Contributors, c)
+		}
+	}
+	for _, g := range genres {
+		if b, ok := index[g.BookID]; ok {
+			b.Genres = append(b.Genres, g.Genre)
+		}
+	}
+	for _, t := range tags {
+		if b, ok := index[t.BookID]; ok {
+			b.Tags = append(b.Tags, t.Tag)
+		}
+	}
+	return nil
+}
+
+func insertContributors(ctx context.Context, db postgres.Querier, bookID int, contributors []model.BookContributor) error {
+	for _, c := range contributors {
+		c.BookID = bookID
+		q, args := query.BuildInsertQuery("book_authors", query.Assignments(c, "name"))
+		_, err := db.ExecContext(ctx, q, args...)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// idArray passes ids as a Postgres array, to be matched with = ANY($n).
+func idArray(ids []int) interface{} {
+	values := make([]int64, len(ids))
+	for i, id := range ids {
+		values[i] = int64(id)
+	}
+	return pq.Array(values)
+}
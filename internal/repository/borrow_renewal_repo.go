@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BorrowRenewalRepository defines the interface for the renewal audit log
+type BorrowRenewalRepository interface {
+	GetRenewalsByBorrowID(ctx context.Context, borrowID int) ([]model.BorrowRenewal, error)
+	CreateRenewal(ctx context.Context, r model.BorrowRenewal) (int, error)
+}
+
+// borrowRenewalRepository is the concrete implementation of BorrowRenewalRepository
+type borrowRenewalRepository struct {
+	db *sqlx.DB
+}
+
+// NewBorrowRenewalRepository creates a new instance of BorrowRenewalRepository
+func NewBorrowRenewalRepository(db *sqlx.DB) BorrowRenewalRepository {
+	return &borrowRenewalRepository{db: db}
+}
+
+func (r *borrowRenewalRepository) GetRenewalsByBorrowID(ctx context.Context, borrowID int) ([]model.BorrowRenewal, error) {
+	var renewals []model.BorrowRenewal
+	err := conn(ctx, r.db).SelectContext(ctx, &renewals, `
+		SELECT id, borrow_id, attempted_at, succeeded, previous_due_at, new_due_at, reason
+		FROM borrow_renewals
+		WHERE borrow_id=$1
+		ORDER BY attempted_at, id`, borrowID)
+	return renewals, err
+}
+
+func (r *borrowRenewalRepository) CreateRenewal(ctx context.Context, renewal model.BorrowRenewal) (int, error) {
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO borrow_renewals (borrow_id, attempted_at, succeeded, previous_due_at, new_due_at, reason)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		renewal.BorrowID, renewal.AttemptedAt, renewal.Succeeded, renewal.PreviousDueAt, renewal.NewDueAt, renewal.Reason,
+	).Scan(&id)
+	return id, err
+}
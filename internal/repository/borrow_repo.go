@@ -10,13 +10,27 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// BorrowSchema lists the columns borrows can be listed by.
+var BorrowSchema = query.Register("borrows", model.Borrow{}, nil)
+
 type BorrowRepository interface {
 	GetAllBorrowLists(ctx context.Context, opts query.QueryOptions) ([]model.Borrow, error)
+	CountBorrows(ctx context.Context, opts query.QueryOptions) (int, error)
+	AggregateBorrows(ctx context.Context, opts query.QueryOptions) ([]map[string]interface{}, error)
 	GetBorrowByID(ctx context.Context, id int) (*model.Borrow, error)
-	GetBorrowByUserName(ctx context.Context, name string) (*model.Borrow, error)
 	CreateBorrow(ctx context.Context, b model.Borrow) (int, error)
-	UpdateBorrow(ctx context.Context, b model.Borrow) error
+	// UpdateBorrow writes the columns of a borrow that changed from old.
+	UpdateBorrow(ctx context.Context, old, b model.Borrow) error
+	ReturnBorrow(ctx context.Context, id int, returnedAt int64, branchID int) error
+	// RenewBorrow returns ErrNoRowsUpdated when the borrow was returned or
+	// already renewed maxRenewals times.
+	RenewBorrow(ctx context.Context, id, maxRenewals int, dueAt int64) error
 	DeleteBorrow(ctx context.Context, id int) error
+	// GetBorrowHistory returns a page of the loans of a member, current ones first, then most recent first.
+	GetBorrowHistory(ctx context.Context, memberID int, loans string, limit, offset int) ([]model.BorrowHistoryEntry, error)
+	CountBorrowHistory(ctx context.Context, memberID int, loans string) (int, error)
+	// CountOpenBorrows counts the loans a member has not returned yet, only those of books in bookCategory unless it is empty.
+	CountOpenBorrows(ctx context.Context, memberID int, bookCategory string) (int, error)
 }
 
 type borrowRepository struct {
@@ -30,24 +44,34 @@ func NewBorrowRepository(db *sqlx.DB) BorrowRepository {
 }
 
 func (r *borrowRepository) GetAllBorrowLists(ctx context.Context, opts query.QueryOptions) ([]model.Borrow, error) {
-	q, args := query.BuildSelectQuery("borrows", opts)
+	if len(opts.Fields) == 0 {
+		opts.Fields = BorrowSchema.Columns()
+	}
+	q, args := query.BuildSelectQuery(BorrowSchema.Table, opts)
 	var borrows []model.Borrow
-	err := r.db.SelectContext(ctx, &borrows, q, args...)
+	err := conn(ctx, r.db).SelectContext(ctx, &borrows, q, args...)
 	return borrows, err
 }
 
-func (r *borrowRepository) GetBorrowByID(ctx context.Context, id int) (*model.Borrow, error) {
-	var borrow model.Borrow
-	err := r.db.GetContext(ctx, &borrow, "SELECT * FROM borrows WHERE id=$1", id)
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
-	return &borrow, err
+func (r *borrowRepository) CountBorrows(ctx context.Context, opts query.QueryOptions) (int, error) {
+	q, args := query.BuildCountQuery(BorrowSchema.Table, opts)
+	var count int
+	err := conn(ctx, r.db).GetContext(ctx, &count, q, args...)
+	return count, err
 }
 
-func (r *borrowRepository) GetBorrowByUserName(ctx context.Context, name string) (*model.Borrow, error) {
+func (r *borrowRepository) AggregateBorrows(ctx context.Context, opts query.QueryOptions) ([]map[string]interface{}, error) {
+	q, args := query.BuildAggregateQuery(BorrowSchema.Table, opts)
+	return selectAggregates(ctx, r.db, q, args...)
+}
+
+func (r *borrowRepository) GetBorrowByID(ctx context.Context, id int) (*model.Borrow, error) {
 	var borrow model.Borrow
-	err := r.db.GetContext(ctx, &borrow, "SELECT * FROM borrows WHERE user_name=$1", name)
+	q, args := query.BuildSelectQuery(BorrowSchema.Table, query.QueryOptions{
+		Fields:  BorrowSchema.Columns(),
+		Filters: []query.Filter{{Field: "id", Operator: "eq", Value: id}},
+	})
+	err := conn(ctx, r.db).GetContext(ctx, &borrow, q, args...)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -56,29 +80,49 @@ func (r *borrowRepository) GetBorrowByUserName(ctx context.Context, name string)
 
 func (r *borrowRepository) CreateBorrow(ctx context.Context, b model.Borrow) (int, error) {
 	var id int
-	err := r.db.QueryRowContext(ctx,
-		"INSERT INTO borrows (book_id, user_name, borrowed_at) VALUES ($1, $2, $3) RETURNING id",
-		b.BookID, b.UserName, b.BorrowedAt,
-	).Scan(&id)
+	// New borrows are open and were never renewed
+	q, args := query.BuildInsertQuery(BorrowSchema.Table,
+		query.Assignments(b, "id", "return_branch_id", "returned_at", "renewal_count"), "id")
+	err := conn(ctx, r.db).QueryRowContext(ctx, q, args...).Scan(&id)
 	return id, err
 }
 
-func (r *borrowRepository) UpdateBorrow(ctx context.Context, b model.Borrow) error {
-	res, err := r.db.ExecContext(ctx,
-		"UPDATE borrows SET book_id=$1, user_name=$2, borrowed_at=$3 WHERE id=$4",
-		b.BookID, b.UserName, b.BorrowedAt, b.ID)
+func (r *borrowRepository) UpdateBorrow(ctx context.Context, old, b model.Borrow) error {
+	changes := query.Changes(old, b, "id")
+	if len(changes) == 0 {
+		return nil
+	}
+	q, args := query.BuildUpdateQuery(BorrowSchema.Table, changes, []query.Filter{{Field: "id", Operator: "eq", Value: b.ID}})
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+// ReturnBorrow closes an open borrow at the given branch. Borrows that were already returned are left untouched.
+func (r *borrowRepository) ReturnBorrow(ctx context.Context, id int, returnedAt int64, branchID int) error {
+	q, args := query.BuildUpdateQuery(BorrowSchema.Table,
+		[]query.Assignment{{Column: "returned_at", Value: returnedAt}, {Column: "return_branch_id", Value: branchID}},
+		openBorrow(id))
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
 	if err != nil {
 		return err
 	}
 	rows, _ := res.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("no rows updated")
+		return ErrNoRowsUpdated
 	}
 	return nil
 }
 
 func (r *borrowRepository) DeleteBorrow(ctx context.Context, id int) error {
-	res, err := r.db.ExecContext(ctx, "DELETE FROM borrows WHERE id=$1", id)
+	q, args := query.BuildDeleteQuery(BorrowSchema.Table, []query.Filter{{Field: "id", Operator: "eq", Value: id}})
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
 	if err != nil {
 		return err
 	}
@@ -88,3 +132,79 @@ func (r *borrowRepository) DeleteBorrow(ctx context.Context, id int) error {
 	}
 	return nil
 }
+
+// RenewBorrow moves the due date of an open borrow and counts the renewal, as
+// long as the borrow has been renewed fewer than maxRenewals times.
+func (r *borrowRepository) RenewBorrow(ctx context.Context, id, maxRenewals int, dueAt int64) error {
+	q, args := query.BuildUpdateQuery(BorrowSchema.Table,
+		[]query.Assignment{{Column: "due_at", Value: dueAt}, {Column: "renewal_count", Value: query.Raw("renewal_count + 1")}},
+		append(openBorrow(id), query.Filter{Field: "renewal_count", Operator: "lt", Value: maxRenewals}))
+	res, err := conn(ctx, r.db).ExecContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+// openBorrow selects a borrow by id as long as it was not returned.
+func openBorrow(id int) []query.Filter {
+	return []query.Filter{
+		{Field: "id", Operator: "eq", Value: id},
+		{Field: "returned_at", Operator: "isnull"},
+	}
+}
+
+func (r *borrowRepository) CountOpenBorrows(ctx context.Context, memberID int, bookCategory string) (int, error) {
+	var count int
+	err := conn(ctx, r.db).GetContext(ctx, &count, `
+		SELECT COUNT(*)
+		FROM borrows br
+		JOIN books b ON b.id = br.book_id
+		WHERE br.member_id = $1 AND br.returned_at IS NULL AND ($2 = '' OR b.category = $2)`,
+		memberID, bookCategory)
+	return count, err
+}
+
+// historyCondition restricts the borrow history of a member to current or past loans, or none of them when loans is empty.
+func historyCondition(loans string) string {
+	switch loans {
+	case model.BorrowHistoryCurrent:
+		return "br.member_id = $1 AND br.returned_at IS NULL"
+	case model.BorrowHistoryPast:
+		return "br.member_id = $1 AND br.returned_at IS NOT NULL"
+	default:
+		return "br.member_id = $1"
+	}
+}
+
+func (r *borrowRepository) GetBorrowHistory(ctx context.Context, memberID int, loans string, limit, offset int) ([]model.BorrowHistoryEntry, error) {
+	var entries []model.BorrowHistoryEntry
+	err := conn(ctx, r.db).SelectContext(ctx, &entries, `
+		SELECT br.id, br.book_id, br.copy_id, br.branch_id, br.return_branch_id, br.member_id,
+			br.borrowed_at, br.due_at, br.returned_at, br.renewal_count,
+			b.title AS book_title,
+			COALESCE((
+				SELECT string_agg(a.name, ', ' ORDER BY ba.position)
+				FROM book_authors ba
+				JOIN authors a ON a.id = ba.author_id
+				WHERE ba.book_id = b.id AND ba.role = 'author'
+			), '') AS author_name
+		FROM borrows br
+		JOIN books b ON b.id = br.book_id
+		WHERE `+historyCondition(loans)+`
+		ORDER BY br.returned_at IS NOT NULL, br.borrowed_at DESC, br.id DESC
+		LIMIT $2 OFFSET $3`,
+		memberID, limit, offset)
+	return entries, err
+}
+
+func (r *borrowRepository) CountBorrowHistory(ctx context.Context, memberID int, loans string) (int, error) {
+	var count int
+	err := conn(ctx, r.db).GetContext(ctx, &count,
+		"SELECT COUNT(*) FROM borrows br WHERE "+historyCondition(loans), memberID)
+	return count, err
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BranchSchema lists the columns branches can be listed by.
+var BranchSchema = query.Register("branches", model.Branch{}, nil)
+
+// BranchRepository defines the interface for branch-related data operations
+type BranchRepository interface {
+	GetAllBranches(ctx context.Context, opts query.QueryOptions) ([]model.Branch, error)
+	GetBranchByID(ctx context.Context, id int) (*model.Branch, error)
+	GetBranchByCode(ctx context.Context, code string) (*model.Branch, error)
+	CreateBranch(ctx context.Context, b model.Branch) (int, error)
+	UpdateBranch(ctx context.Context, b model.Branch) error
+	DeleteBranch(ctx context.Context, id int) error
+}
+
+// branchRepository is the concrete implementation of BranchRepository
+type branchRepository struct {
+	db *sqlx.DB
+}
+
+// NewBranchRepository creates a new instance of BranchRepository
+func NewBranchRepository(db *sqlx.DB) BranchRepository {
+	return &branchRepository{db: db}
+}
+
+func (r *branchRepository) GetAllBranches(ctx context.Context, opts query.QueryOptions) ([]model.Branch, error) {
+	q, args := query.BuildSelectQuery(BranchSchema.Table, opts)
+	var branches []model.Branch
+	err := conn(ctx, r.db).SelectContext(ctx, &branches, q, args...)
+	return branches, err
+}
+
+func (r *branchRepository) GetBranchByID(ctx context.Context, id int) (*model.Branch, error) {
+	var branch model.Branch
+	err := conn(ctx, r.db).GetContext(ctx, &branch, "SELECT id, code, name, address FROM branches WHERE id=$1", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &branch, err
+}
+
+func (r *branchRepository) GetBranchByCode(ctx context.Context, code string) (*model.Branch, error) {
+	var branch model.Branch
+	err := conn(ctx, r.db).GetContext(ctx, &branch, "SELECT id, code, name, address FROM branches WHERE code=$1", code)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &branch, err
+}
+
+func (r *branchRepository) CreateBranch(ctx context.Context, b model.Branch) (int, error) {
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"INSERT INTO branches (code, name, address) VALUES ($1, $2, $3) RETURNING id",
+		b.Code, b.Name, b.Address,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *branchRepository) UpdateBranch(ctx context.Context, b model.Branch) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE branches SET code=$1, name=$2, address=$3 WHERE id=$4",
+		b.Code, b.Name, b.Address, b.ID)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+// DeleteBranch deletes a branch. It returns ErrReferenced while copies, borrows or transfers still refer to it.
+func (r *branchRepository) DeleteBranch(ctx context.Context, id int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM branches WHERE id=$1", id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrReferenced
+		}
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("no rows deleted")
+	}
+	return nil
+}
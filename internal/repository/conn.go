@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"borrow_book/internal/infra/database/postgres"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// conn returns the transaction of a postgres.TxManager carried by ctx, or the
+// pool outside of one, for repositories to join the unit of work of their caller.
+func conn(ctx context.Context, db *sqlx.DB) postgres.Querier {
+	return postgres.Conn(ctx, db)
+}
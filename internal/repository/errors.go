@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ErrReferenced is returned when a row cannot be deleted because other rows still reference it.
+var ErrReferenced = errors.New("record is still referenced")
+
+// ErrNoRowsUpdated is returned when an update or delete matches no row, because
+// the row does not exist or no longer is in the state the update expects.
+var ErrNoRowsUpdated = errors.New("no rows updated")
+
+// isForeignKeyViolation reports whether err is a PostgreSQL foreign key violation.
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// FineEntrySchema lists the columns fine_entries can be listed by.
+var FineEntrySchema = query.Register("fine_entries", model.FineEntry{}, nil)
+
+// FineRepository defines the interface for fines ledger data operations
+type FineRepository interface {
+	GetAllEntries(ctx context.Context, opts query.QueryOptions) ([]model.FineEntry, error)
+	GetEntryByID(ctx context.Context, id int) (*model.FineEntry, error)
+	CreateEntry(ctx context.Context, f model.FineEntry) (int, error)
+	GetBalance(ctx context.Context, memberID int) (int64, error)
+	GetOutstandingBalances(ctx context.Context) ([]model.FineBalance, error)
+}
+
+// fineRepository is the concrete implementation of FineRepository
+type fineRepository struct {
+	db *sqlx.DB
+}
+
+// NewFineRepository creates a new instance of FineRepository
+func NewFineRepository(db *sqlx.DB) FineRepository {
+	return &fineRepository{db: db}
+}
+
+// balanceExpr sums the ledger of a patron: fines add to the balance, payments and waivers settle it.
+const balanceExpr = "COALESCE(SUM(CASE WHEN kind = 'fine' THEN amount ELSE -amount END), 0)"
+
+func (r *fineRepository) GetAllEntries(ctx context.Context, opts query.QueryOptions) ([]model.FineEntry, error) {
+	q, args := query.BuildSelectQuery(FineEntrySchema.Table, opts)
+	var entries []model.FineEntry
+	err := conn(ctx, r.db).SelectContext(ctx, &entries, q, args...)
+	return entries, err
+}
+
+func (r *fineRepository) GetEntryByID(ctx context.Context, id int) (*model.FineEntry, error) {
+	var entry model.FineEntry
+	err := conn(ctx, r.db).GetContext(ctx, &entry,
+		"SELECT id, member_id, borrow_id, kind, amount, reason, created_at FROM fine_entries WHERE id=$1", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &entry, err
+}
+
+func (r *fineRepository) CreateEntry(ctx context.Context, f model.FineEntry) (int, error) {
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"INSERT INTO fine_entries (member_id, borrow_id, kind, amount, reason, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
+		f.MemberID, f.BorrowID, f.Kind, f.Amount, f.Reason, f.CreatedAt,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *fineRepository) GetBalance(ctx context.Context, memberID int) (int64, error) {
+	var balance int64
+	err := conn(ctx, r.db).GetContext(ctx, &balance,
+		"SELECT "+balanceExpr+" FROM fine_entries WHERE member_id=$1", memberID)
+	return balance, err
+}
+
+// GetOutstandingBalances returns every patron who still owes money, highest balance first.
+func (r *fineRepository) GetOutstandingBalances(ctx context.Context) ([]model.FineBalance, error) {
+	var balances []model.FineBalance
+	err := conn(ctx, r.db).SelectContext(ctx, &balances, `
+		SELECT f.member_id, m.name AS member_name, `+balanceExpr+` AS balance
+		FROM fine_entries f
+		JOIN members m ON m.id = f.member_id
+		GROUP BY f.member_id, m.name
+		HAVING `+balanceExpr+` > 0
+		ORDER BY balance DESC, f.member_id`)
+	return balances, err
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// GenreSchema lists the columns genres can be listed by.
+var GenreSchema = query.Register("genres", model.Genre{}, nil)
+
+// GenreRepository defines the interface for genre-related data operations
+type GenreRepository interface {
+	GetAllGenres(ctx context.Context, opts query.QueryOptions) ([]model.Genre, error)
+	GetGenreByID(ctx context.Context, id int) (*model.Genre, error)
+	// GetGenreByName returns the genre of that name under the parent, or among the top-level genres when parentID is nil.
+	GetGenreByName(ctx context.Context, parentID *int, name string) (*model.Genre, error)
+	// IsDescendant reports whether genre id is ancestorID itself or one of its descendants.
+	IsDescendant(ctx context.Context, id, ancestorID int) (bool, error)
+	CreateGenre(ctx context.Context, g model.Genre) (int, error)
+	UpdateGenre(ctx context.Context, g model.Genre) error
+	DeleteGenre(ctx context.Context, id int) error
+	AddBookGenre(ctx context.Context, bookID, genreID int) error
+	RemoveBookGenre(ctx context.Context, bookID, genreID int) error
+}
+
+// genreRepository is the concrete implementation of GenreRepository
+type genreRepository struct {
+	db *sqlx.DB
+}
+
+// NewGenreRepository creates a new instance of GenreRepository
+func NewGenreRepository(db *sqlx.DB) GenreRepository {
+	return &genreRepository{db: db}
+}
+
+func (r *genreRepository) GetAllGenres(ctx context.Context, opts query.QueryOptions) ([]model.Genre, error) {
+	q, args := query.BuildSelectQuery(GenreSchema.Table, opts)
+	var genres []model.Genre
+	err := conn(ctx, r.db).SelectContext(ctx, &genres, q, args...)
+	return genres, err
+}
+
+func (r *genreRepository) GetGenreByID(ctx context.Context, id int) (*model.Genre, error) {
+	var genre model.Genre
+	err := conn(ctx, r.db).GetContext(ctx, &genre, "SELECT id, name, parent_id FROM genres WHERE id=$1", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &genre, err
+}
+
+func (r *genreRepository) GetGenreByName(ctx context.Context, parentID *int, name string) (*model.Genre, error) {
+	var genre model.Genre
+	err := conn(ctx, r.db).GetContext(ctx, &genre,
+		"SELECT id, name, parent_id FROM genres WHERE parent_id IS NOT DISTINCT FROM $1 AND lower(name) = lower($2)",
+		parentID, name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &genre, err
+}
+
+func (r *genreRepository) IsDescendant(ctx context.Context, id, ancestorID int) (bool, error) {
+	var found bool
+	err := conn(ctx, r.db).GetContext(ctx, &found, `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM genres WHERE id = $2
+			UNION
+			SELECT g.id FROM genres g JOIN subtree s ON g.parent_id = s.id
+		)
+		SELECT EXISTS (SELECT 1 FROM subtree WHERE id = $1)`, id, ancestorID)
+	return found, err
+}
+
+func (r *genreRepository) CreateGenre(ctx context.Context, g model.Genre) (int, error) {
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"INSERT INTO genres (name, parent_id) VALUES ($1, $2) RETURNING id",
+		g.Name, g.ParentID,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *genreRepository) UpdateGenre(ctx context.Context, g model.Genre) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE genres SET name=$1, parent_id=$2 WHERE id=$3",
+		g.Name, g.ParentID, g.ID)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+// DeleteGenre deletes a genre without sub-genres. It returns ErrReferenced while sub-genres remain.
+func (r *genreRepository) DeleteGenre(ctx context.Context, id int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM genres WHERE id=$1", id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrReferenced
+		}
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("no rows deleted")
+	}
+	return nil
+}
+
+func (r *genreRepository) AddBookGenre(ctx context.Context, bookID, genreID int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		"INSERT INTO book_genres (book_id, genre_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		bookID, genreID)
+	return err
+}
+
+func (r *genreRepository) RemoveBookGenre(ctx context.Context, bookID, genreID int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"DELETE FROM book_genres WHERE book_id=$1 AND genre_id=$2", bookID, genreID)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("no rows deleted")
+	}
+	return nil
+}
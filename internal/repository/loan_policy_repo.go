@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// LoanPolicySchema lists the columns loan_policies can be listed by.
+var LoanPolicySchema = query.Register("loan_policies", model.LoanPolicy{}, nil)
+
+// LoanPolicyRepository defines the interface for loan policy data operations
+type LoanPolicyRepository interface {
+	GetAllPolicies(ctx context.Context, opts query.QueryOptions) ([]model.LoanPolicy, error)
+	GetPolicyByID(ctx context.Context, id int) (*model.LoanPolicy, error)
+	// GetPolicy returns the policy of exactly this member and book category, an empty book category selecting the general policy.
+	GetPolicy(ctx context.Context, memberCategory, bookCategory string) (*model.LoanPolicy, error)
+	CreatePolicy(ctx context.Context, p model.LoanPolicy) (int, error)
+	UpdatePolicy(ctx context.Context, p model.LoanPolicy) error
+	DeletePolicy(ctx context.Context, id int) error
+}
+
+// loanPolicyRepository is the concrete implementation of LoanPolicyRepository
+type loanPolicyRepository struct {
+	db *sqlx.DB
+}
+
+// NewLoanPolicyRepository creates a new instance of LoanPolicyRepository
+func NewLoanPolicyRepository(db *sqlx.DB) LoanPolicyRepository {
+	return &loanPolicyRepository{db: db}
+}
+
+func (r *loanPolicyRepository) GetAllPolicies(ctx context.Context, opts query.QueryOptions) ([]model.LoanPolicy, error) {
+	q, args := query.BuildSelectQuery(LoanPolicySchema.Table, opts)
+	var policies []model.LoanPolicy
+	err := conn(ctx, r.db).SelectContext(ctx, &policies, q, args...)
+	return policies, err
+}
+
+func (r *loanPolicyRepository) GetPolicyByID(ctx context.Context, id int) (*model.LoanPolicy, error) {
+	var policy model.LoanPolicy
+	err := conn(ctx, r.db).GetContext(ctx, &policy, `
+		SELECT id, member_category, book_category, max_loans, loan_period_days, max_renewals
+		FROM loan_policies WHERE id=$1`, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &policy, err
+}
+
+func (r *loanPolicyRepository) GetPolicy(ctx context.Context, memberCategory, bookCategory string) (*model.LoanPolicy, error) {
+	var policy model.LoanPolicy
+	err := conn(ctx, r.db).GetContext(ctx, &policy, `
+		SELECT id, member_category, book_category, max_loans, loan_period_days, max_renewals
+		FROM loan_policies WHERE member_category=$1 AND book_category=$2`, memberCategory, bookCategory)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &policy, err
+}
+
+func (r *loanPolicyRepository) CreatePolicy(ctx context.Context, p model.LoanPolicy) (int, error) {
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO loan_policies (member_category, book_category, max_loans, loan_period_days, max_renewals)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		p.MemberCategory, p.BookCategory, p.MaxLoans, p.LoanPeriodDays, p.MaxRenewals,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *loanPolicyRepository) UpdatePolicy(ctx context.Context, p model.LoanPolicy) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE loan_policies
+		SET member_category=$1, book_category=$2, max_loans=$3, loan_period_days=$4, max_renewals=$5
+		WHERE id=$6`,
+		p.MemberCategory, p.BookCategory, p.MaxLoans, p.LoanPeriodDays, p.MaxRenewals, p.ID)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+func (r *loanPolicyRepository) DeletePolicy(ctx context.Context, id int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM loan_policies WHERE id=$1", id)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("no rows deleted")
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// MemberSchema lists the columns members can be listed by.
+var MemberSchema = query.Register("members", model.Member{}, nil)
+
+// MemberRepository defines the interface for member-related data operations
+type MemberRepository interface {
+	GetAllMembers(ctx context.Context, opts query.QueryOptions) ([]model.Member, error)
+	GetMemberByID(ctx context.Context, id int) (*model.Member, error)
+	// LockMember is GetMemberByID, locking the row of the member until the end
+	// of the transaction carried by ctx.
+	LockMember(ctx context.Context, id int) (*model.Member, error)
+	GetMembersByIDs(ctx context.Context, ids []int) ([]model.Member, error)
+	GetMemberByCardNumber(ctx context.Context, cardNumber string) (*model.Member, error)
+	CreateMember(ctx context.Context, m model.Member) (int, error)
+	UpdateMember(ctx context.Context, m model.Member) error
+	DeleteMember(ctx context.Context, id int) error
+}
+
+// memberRepository is the concrete implementation of MemberRepository
+type memberRepository struct {
+	db *sqlx.DB
+}
+
+// NewMemberRepository creates a new instance of MemberRepository
+func NewMemberRepository(db *sqlx.DB) MemberRepository {
+	return &memberRepository{db: db}
+}
+
+func (r *memberRepository) GetAllMembers(ctx context.Context, opts query.QueryOptions) ([]model.Member, error) {
+	q, args := query.BuildSelectQuery(MemberSchema.Table, opts)
+	var members []model.Member
+	err := conn(ctx, r.db).SelectContext(ctx, &members, q, args...)
+	return members, err
+}
+
+func (r *memberRepository) GetMemberByID(ctx context.Context, id int) (*model.Member, error) {
+	var member model.Member
+	err := conn(ctx, r.db).GetContext(ctx, &member,
+		"SELECT id, card_number, name, email, phone, status, category, expires_at FROM members WHERE id=$1", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &member, err
+}
+
+func (r *memberRepository) LockMember(ctx context.Context, id int) (*model.Member, error) {
+	var member model.Member
+	err := conn(ctx, r.db).GetContext(ctx, &member,
+		"SELECT id, card_number, name, email, phone, status, category, expires_at FROM members WHERE id=$1 FOR UPDATE", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &member, err
+}
+
+func (r *memberRepository) GetMembersByIDs(ctx context.Context, ids []int) ([]model.Member, error) {
+	var members []model.Member
+	err := conn(ctx, r.db).SelectContext(ctx, &members,
+		"SELECT id, card_number, name, email, phone, status, category, expires_at FROM members WHERE id = ANY($1)", idArray(ids))
+	return members, err
+}
+
+func (r *memberRepository) GetMemberByCardNumber(ctx context.Context, cardNumber string) (*model.Member, error) {
+	var member model.Member
+	err := conn(ctx, r.db).GetContext(ctx, &member,
+		"SELECT id, card_number, name, email, phone, status, category, expires_at FROM members WHERE card_number=$1", cardNumber)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &member, err
+}
+
+func (r *memberRepository) CreateMember(ctx context.Context, m model.Member) (int, error) {
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"INSERT INTO members (card_number, name, email, phone, status, category, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id",
+		m.CardNumber, m.Name, m.Email, m.Phone, m.Status, m.Category, m.ExpiresAt,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *memberRepository) UpdateMember(ctx context.Context, m model.Member) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE members SET card_number=$1, name=$2, email=$3, phone=$4, status=$5, category=$6, expires_at=$7 WHERE id=$8",
+		m.CardNumber, m.Name, m.Email, m.Phone, m.Status, m.Category, m.ExpiresAt, m.ID)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+func (r *memberRepository) DeleteMember(ctx context.Context, id int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM members WHERE id=$1", id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrReferenced
+		}
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("no rows deleted")
+	}
+	return nil
+}
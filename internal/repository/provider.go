@@ -5,5 +5,18 @@ import "github.com/google/wire"
 var ProviderSetRepository = wire.NewSet(
 	NewBookRepository,
 	NewAuthorRepository,
+	NewPublisherRepository,
 	NewBorrowRepository,
+	NewBookCopyRepository,
+	NewReservationRepository,
+	NewFineRepository,
+	NewBorrowRenewalRepository,
+	NewMemberRepository,
+	NewLoanPolicyRepository,
+	NewGenreRepository,
+	NewTagRepository,
+	NewBranchRepository,
+	NewTransferRepository,
+	NewBookCoverRepository,
+	NewSearchRepository,
 )
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PublisherSchema lists the columns publishers can be listed by.
+var PublisherSchema = query.Register("publishers", model.Publisher{}, nil)
+
+// PublisherRepository defines the interface for publisher-related data operations
+type PublisherRepository interface {
+	GetAllPublishers(ctx context.Context, opts query.QueryOptions) ([]model.Publisher, error)
+	GetPublisherByID(ctx context.Context, id int) (*model.Publisher, error)
+	GetPublishersByIDs(ctx context.Context, ids []int) ([]model.Publisher, error)
+	GetPublisherByName(ctx context.Context, name string) (*model.Publisher, error)
+	CreatePublisher(ctx context.Context, p model.Publisher) (int, error)
+	UpdatePublisher(ctx context.Context, p model.Publisher) error
+	DeletePublisher(ctx context.Context, id int) error
+}
+
+// publisherRepository is the concrete implementation of PublisherRepository
+type publisherRepository struct {
+	db *sqlx.DB
+}
+
+// NewPublisherRepository creates a new instance of PublisherRepository
+func NewPublisherRepository(db *sqlx.DB) PublisherRepository {
+	return &publisherRepository{db: db}
+}
+
+func (r *publisherRepository) GetAllPublishers(ctx context.Context, opts query.QueryOptions) ([]model.Publisher, error) {
+	q, args := query.BuildSelectQuery(PublisherSchema.Table, opts)
+	var publishers []model.Publisher
+	err := conn(ctx, r.db).SelectContext(ctx, &publishers, q, args...)
+	return publishers, err
+}
+
+func (r *publisherRepository) GetPublisherByID(ctx context.Context, id int) (*model.Publisher, error) {
+	var publisher model.Publisher
+	err := conn(ctx, r.db).GetContext(ctx, &publisher, "SELECT id, name FROM publishers WHERE id=$1", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &publisher, err
+}
+
+func (r *publisherRepository) GetPublishersByIDs(ctx context.Context, ids []int) ([]model.Publisher, error) {
+	var publishers []model.Publisher
+	err := conn(ctx, r.db).SelectContext(ctx, &publishers, "SELECT id, name FROM publishers WHERE id = ANY($1)", idArray(ids))
+	return publishers, err
+}
+
+func (r *publisherRepository) GetPublisherByName(ctx context.Context, name string) (*model.Publisher, error) {
+	var publisher model.Publisher
+	err := conn(ctx, r.db).GetContext(ctx, &publisher, "SELECT id, name FROM publishers WHERE lower(name)=lower($1)", name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &publisher, err
+}
+
+func (r *publisherRepository) CreatePublisher(ctx context.Context, p model.Publisher) (int, error) {
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"INSERT INTO publishers (name) VALUES ($1) RETURNING id",
+		p.Name,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *publisherRepository) UpdatePublisher(ctx context.Context, p model.Publisher) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE publishers SET name=$1 WHERE id=$2",
+		p.Name, p.ID)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+// DeletePublisher deletes a publisher. It returns ErrReferenced while books still name it.
+func (r *publisherRepository) DeletePublisher(ctx context.Context, id int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM publishers WHERE id=$1", id)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return ErrReferenced
+		}
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("no rows deleted")
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ReservationRepository defines the interface for reservation data operations
+type ReservationRepository interface {
+	GetActiveReservationsByBookID(ctx context.Context, bookID int) ([]model.Reservation, error)
+	GetReservationByID(ctx context.Context, id int) (*model.Reservation, error)
+	GetActiveReservation(ctx context.Context, bookID, memberID int) (*model.Reservation, error)
+	GetNextPendingReservation(ctx context.Context, bookID int) (*model.Reservation, error)
+	CountActiveReservations(ctx context.Context, bookID, exceptMemberID int) (int, error)
+	GetExpiredReservations(ctx context.Context, bookID int, now int64) ([]model.Reservation, error)
+	CreateReservation(ctx context.Context, r model.Reservation) (int, error)
+	MarkReservationReady(ctx context.Context, id, copyID int, readyAt, expiresAt int64) error
+	// UpdateReservationStatus moves a reservation from status from to status to,
+	// and returns ErrNoRowsUpdated when it no longer is in status from.
+	UpdateReservationStatus(ctx context.Context, id int, from, to string) error
+}
+
+// reservationRepository is the concrete implementation of ReservationRepository
+type reservationRepository struct {
+	db *sqlx.DB
+}
+
+// NewReservationRepository creates a new instance of ReservationRepository
+func NewReservationRepository(db *sqlx.DB) ReservationRepository {
+	return &reservationRepository{db: db}
+}
+
+// GetActiveReservationsByBookID returns the queue of a book: ready reservations
+// first, then pending ones in the order they were placed.
+func (r *reservationRepository) GetActiveReservationsByBookID(ctx context.Context, bookID int) ([]model.Reservation, error) {
+	var reservations []model.Reservation
+	err := conn(ctx, r.db).SelectContext(ctx, &reservations, `
+		SELECT id, book_id, member_id, status, copy_id, created_at, ready_at, expires_at,
+			CASE WHEN status = $2
+				THEN ROW_NUMBER() OVER (PARTITION BY status ORDER BY created_at, id)
+				ELSE 0
+			END AS position
+		FROM reservations
+		WHERE book_id = $1 AND status IN ($2, $3)
+		ORDER BY status = $2, created_at, id`,
+		bookID, model.ReservationStatusPending, model.ReservationStatusReady)
+	return reservations, err
+}
+
+func (r *reservationRepository) GetReservationByID(ctx context.Context, id int) (*model.Reservation, error) {
+	var reservation model.Reservation
+	err := conn(ctx, r.db).GetContext(ctx, &reservation, `
+		SELECT id, book_id, member_id, status, copy_id, created_at, ready_at, expires_at,
+			CASE WHEN status = $2 THEN (
+				SELECT COUNT(*) FROM reservations q
+				WHERE q.book_id = r.book_id AND q.status = $2
+					AND (q.created_at, q.id) <= (r.created_at, r.id)
+			) ELSE 0 END AS position
+		FROM reservations r
+		WHERE id = $1`,
+		id, model.ReservationStatusPending)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &reservation, err
+}
+
+// GetActiveReservation returns the pending or ready reservation of a patron on a book, if any.
+func (r *reservationRepository) GetActiveReservation(ctx context.Context, bookID, memberID int) (*model.Reservation, error) {
+	var reservation model.Reservation
+	err := conn(ctx, r.db).GetContext(ctx, &reservation, `
+		SELECT id, book_id, member_id, status, copy_id, created_at, ready_at, expires_at
+		FROM reservations
+		WHERE book_id = $1 AND member_id = $2 AND status IN ($3, $4)`,
+		bookID, memberID, model.ReservationStatusPending, model.ReservationStatusReady)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &reservation, err
+}
+
+// GetNextPendingReservation returns the oldest pending reservation of a book, if any.
+func (r *reservationRepository) GetNextPendingReservation(ctx context.Context, bookID int) (*model.Reservation, error) {
+	var reservation model.Reservation
+	err := conn(ctx, r.db).GetContext(ctx, &reservation, `
+		SELECT id, book_id, member_id, status, copy_id, created_at, ready_at, expires_at
+		FROM reservations
+		WHERE book_id = $1 AND status = $2
+		ORDER BY created_at, id
+		LIMIT 1`,
+		bookID, model.ReservationStatusPending)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &reservation, err
+}
+
+// CountActiveReservations counts the pending and ready reservations on a book placed by other patrons than exceptMemberID.
+func (r *reservationRepository) CountActiveReservations(ctx context.Context, bookID, exceptMemberID int) (int, error) {
+	var count int
+	err := conn(ctx, r.db).GetContext(ctx, &count, `
+		SELECT COUNT(*)
+		FROM reservations
+		WHERE book_id = $1 AND member_id <> $2 AND status IN ($3, $4)`,
+		bookID, exceptMemberID, model.ReservationStatusPending, model.ReservationStatusReady)
+	return count, err
+}
+
+// GetExpiredReservations returns the ready reservations of a book whose pickup deadline passed.
+func (r *reservationRepository) GetExpiredReservations(ctx context.Context, bookID int, now int64) ([]model.Reservation, error) {
+	var reservations []model.Reservation
+	err := conn(ctx, r.db).SelectContext(ctx, &reservations, `
+		SELECT id, book_id, member_id, status, copy_id, created_at, ready_at, expires_at
+		FROM reservations
+		WHERE book_id = $1 AND status = $2 AND expires_at < $3
+		ORDER BY expires_at, id`,
+		bookID, model.ReservationStatusReady, now)
+	return reservations, err
+}
+
+func (r *reservationRepository) CreateReservation(ctx context.Context, res model.Reservation) (int, error) {
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"INSERT INTO reservations (book_id, member_id, status, created_at) VALUES ($1, $2, $3, $4) RETURNING id",
+		res.BookID, res.MemberID, res.Status, res.CreatedAt,
+	).Scan(&id)
+	return id, err
+}
+
+// MarkReservationReady holds a copy for a pending reservation until expiresAt.
+func (r *reservationRepository) MarkReservationReady(ctx context.Context, id, copyID int, readyAt, expiresAt int64) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"UPDATE reservations SET status=$1, copy_id=$2, ready_at=$3, expires_at=$4 WHERE id=$5 AND status=$6",
+		model.ReservationStatusReady, copyID, readyAt, expiresAt, id, model.ReservationStatusPending)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+func (r *reservationRepository) UpdateReservationStatus(ctx context.Context, id int, from, to string) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE reservations SET status=$1 WHERE id=$2 AND status=$3", to, id, from)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
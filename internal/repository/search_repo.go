@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"context"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SearchRepository defines the interface for full-text search operations
+type SearchRepository interface {
+	// Search ranks the books and authors matching a web search style query, parsed
+	// with the given text search configuration. Only the given types are searched.
+	Search(ctx context.Context, language, q string, types []string, limit int) ([]model.SearchHit, error)
+}
+
+// searchRepository is the concrete implementation of SearchRepository
+type searchRepository struct {
+	db *sqlx.DB
+}
+
+// NewSearchRepository creates a new instance of SearchRepository
+func NewSearchRepository(db *sqlx.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// searchQueries select the hits of each type. $1 is the text search configuration
+// and q the parsed query.
+var searchQueries = map[string]string{
+	model.SearchTypeBook: `
+		SELECT 'book' AS type, b.id, b.title AS name,
+			ts_headline($1::regconfig, b.title, q, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS highlight,
+			ts_rank(b.search_vector, q) AS rank
+		FROM books b, websearch_to_tsquery($1::regconfig, $2) q
+		WHERE b.search_vector @@ q`,
+	model.SearchTypeAuthor: `
+		SELECT 'author' AS type, a.id, a.name,
+			ts_headline($1::regconfig, a.name, q, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS highlight,
+			ts_rank(a.search_vector, q) AS rank
+		FROM authors a, websearch_to_tsquery($1::regconfig, $2) q
+		WHERE a.search_vector @@ q`,
+}
+
+func (r *searchRepository) Search(ctx context.Context, language, q string, types []string, limit int) ([]model.SearchHit, error) {
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		parts = append(parts, searchQueries[t])
+	}
+	sql := strings.Join(parts, " UNION ALL ") + " ORDER BY rank DESC, type, id LIMIT $3"
+
+	hits := []model.SearchHit{}
+	err := conn(ctx, r.db).SelectContext(ctx, &hits, sql, language, q, limit)
+	return hits, err
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TagSchema lists the columns tags can be listed by.
+var TagSchema = query.Register("tags", model.Tag{}, nil)
+
+// TagRepository defines the interface for tag-related data operations
+type TagRepository interface {
+	GetAllTags(ctx context.Context, opts query.QueryOptions) ([]model.Tag, error)
+	GetTagByID(ctx context.Context, id int) (*model.Tag, error)
+	GetTagByName(ctx context.Context, name string) (*model.Tag, error)
+	CreateTag(ctx context.Context, t model.Tag) (int, error)
+	UpdateTag(ctx context.Context, t model.Tag) error
+	DeleteTag(ctx context.Context, id int) error
+	AddBookTag(ctx context.Context, bookID, tagID int) error
+	RemoveBookTag(ctx context.Context, bookID, tagID int) error
+}
+
+// tagRepository is the concrete implementation of TagRepository
+type tagRepository struct {
+	db *sqlx.DB
+}
+
+// NewTagRepository creates a new instance of TagRepository
+func NewTagRepository(db *sqlx.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) GetAllTags(ctx context.Context, opts query.QueryOptions) ([]model.Tag, error) {
+	q, args := query.BuildSelectQuery(TagSchema.Table, opts)
+	var tags []model.Tag
+	err := conn(ctx, r.db).SelectContext(ctx, &tags, q, args...)
+	return tags, err
+}
+
+func (r *tagRepository) GetTagByID(ctx context.Context, id int) (*model.Tag, error) {
+	var tag model.Tag
+	err := conn(ctx, r.db).GetContext(ctx, &tag, "SELECT id, name FROM tags WHERE id=$1", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &tag, err
+}
+
+func (r *tagRepository) GetTagByName(ctx context.Context, name string) (*model.Tag, error) {
+	var tag model.Tag
+	err := conn(ctx, r.db).GetContext(ctx, &tag, "SELECT id, name FROM tags WHERE name=$1", name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &tag, err
+}
+
+func (r *tagRepository) CreateTag(ctx context.Context, t model.Tag) (int, error) {
+	var id int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		"INSERT INTO tags (name) VALUES ($1) RETURNING id",
+		t.Name,
+	).Scan(&id)
+	return id, err
+}
+
+func (r *tagRepository) UpdateTag(ctx context.Context, t model.Tag) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE tags SET name=$1 WHERE id=$2", t.Name, t.ID)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return ErrNoRowsUpdated
+	}
+	return nil
+}
+
+func (r *tagRepository) DeleteTag(ctx context.Context, id int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM tags WHERE id=$1", id)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("no rows deleted")
+	}
+	return nil
+}
+
+func (r *tagRepository) AddBookTag(ctx context.Context, bookID, tagID int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		"INSERT INTO book_tags (book_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		bookID, tagID)
+	return err
+}
+
+func (r *tagRepository) RemoveBookTag(ctx context.Context, bookID, tagID int) error {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		"DELETE FROM book_tags WHERE book_id=$1 AND tag_id=$2", bookID, tagID)
+	if err != nil {
+		return err
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("no rows deleted")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/postgres"
+	"borrow_book/internal/infra/database/query"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TransferSchema lists the columns transfers can be listed by.
+var TransferSchema = query.Register("transfers", model.Transfer{}, nil)
+
+// TransferRepository defines the interface for transfer data operations.
+// Every change of a transfer is recorded in its audit trail.
+type TransferRepository interface {
+	GetAllTransfers(ctx context.Context, opts query.QueryOptions) ([]model.Transfer, error)
+	GetTransferByID(ctx context.Context, id int) (*model.Transfer, error)
+	// GetOpenTransferByCopyID returns the requested or in transit transfer of a copy, if any.
+	GetOpenTransferByCopyID(ctx context.Context, copyID int) (*model.Transfer, error)
+	GetTransferEvents(ctx context.Context, transferID int) ([]model.TransferEvent, error)
+	CreateTransfer(ctx context.Context, t model.Transfer) (int, error)
+	AdvanceTransfer(ctx context.Context, id int, from string, e model.TransferEvent) error
+}
+
+// transferRepository is the concrete implementation of TransferRepository
+type transferRepository struct {
+	db *sqlx.DB
+}
+
+// NewTransferRepository creates a new instance of TransferRepository
+func NewTransferRepository(db *sqlx.DB) TransferRepository {
+	return &transferRepository{db: db}
+}
+
+func (r *transferRepository) GetAllTransfers(ctx context.Context, opts query.QueryOptions) ([]model.Transfer, error) {
+	q, args := query.BuildSelectQuery(TransferSchema.Table, opts)
+	var transfers []model.Transfer
+	err := conn(ctx, r.db).SelectContext(ctx, &transfers, q, args...)
+	return transfers, err
+}
+
+func (r *transferRepository) GetTransferByID(ctx context.Context, id int) (*model.Transfer, error) {
+	var t model.Transfer
+	err := conn(ctx, r.db).GetContext(ctx, &t, `
+		SELECT id, copy_id, from_branch_id, to_branch_id, status, requested_at, shipped_at, received_at, note
+		FROM transfers WHERE id=$1`, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &t, err
+}
+
+func (r *transferRepository) GetOpenTransferByCopyID(ctx context.Context, copyID int) (*model.Transfer, error) {
+	var t model.Transfer
+	err := conn(ctx, r.db).GetContext(ctx, &t, `
+		SELECT id, copy_id, from_branch_id, to_branch_id, status, requested_at, shipped_at, received_at, note
+		FROM transfers
+		WHERE copy_id=$1 AND status IN ($2, $3)`,
+		copyID, model.TransferStatusRequested, model.TransferStatusInTransit)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &t, err
+}
+
+func (r *transferRepository) GetTransferEvents(ctx context.Context, transferID int) ([]model.TransferEvent, error) {
+	var events []model.TransferEvent
+	err := conn(ctx, r.db).SelectContext(ctx, &events, `
+		SELECT id, transfer_id, status, occurred_at, note
+		FROM transfer_events
+		WHERE transfer_id=$1
+		ORDER BY occurred_at, id`, transferID)
+	return events, err
+}
+
+// CreateTransfer inserts the transfer and the first event of its audit trail in a single transaction.
+func (r *transferRepository) CreateTransfer(ctx context.Context, t model.Transfer) (int, error) {
+	var id int
+	err := postgres.WithinTx(ctx, r.db, func(ctx context.Context) error {
+		err := conn(ctx, r.db).QueryRowContext(ctx,
+			`INSERT INTO transfers (copy_id, from_branch_id, to_branch_id, status, requested_at, note)
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+			t.CopyID, t.FromBranchID, t.ToBranchID, t.Status, t.RequestedAt, t.Note,
+		).Scan(&id)
+		if err != nil {
+			return err
+		}
+		return insertTransferEvent(ctx, conn(ctx, r.db), model.TransferEvent{
+			TransferID: id,
+			Status:     t.Status,
+			OccurredAt: t.RequestedAt,
+			Note:       t.Note,
+		})
+	})
+	return id, err
+}
+
+// AdvanceTransfer moves a transfer from the from status to the status of the
+// event and records the event, in a single transaction. Shipping and receiving
+// also stamp the transfer with the time of the event.
+func (r *transferRepository) AdvanceTransfer(ctx context.Context, id int, from string, e model.TransferEvent) error {
+	return postgres.WithinTx(ctx, r.db, func(ctx context.Context) error {
+		res, err := conn(ctx, r.db).ExecContext(ctx, `
+			UPDATE transfers SET status=$1,
+				shipped_at = CASE WHEN $1 = $2 THEN $3 ELSE shipped_at END,
+				received_at = CASE WHEN $1 = $4 THEN $3 ELSE received_at END
+			WHERE id=$5 AND status=$6`,
+			e.Status, model.TransferStatusInTransit, e.OccurredAt, model.TransferStatusReceived, id, from)
+		if err != nil {
+			return err
+		}
+		rows, _ := res.RowsAffected()
+		if rows == 0 {
+			return ErrNoRowsUpdated
+		}
+
+		e.TransferID = id
+		return insertTransferEvent(ctx, conn(ctx, r.db), e)
+	})
+}
+
+func insertTransferEvent(ctx context.Context, q postgres.Querier, e model.TransferEvent) error {
+	_, err := q.ExecContext(ctx,
+		"INSERT INTO transfer_events (transfer_id, status, occurred_at, note) VALUES ($1, $2, $3, $4)",
+		e.TransferID, e.Status, e.OccurredAt, e.Note)
+	return err
+}
@@ -7,23 +7,59 @@ import (
 )
 
 type AppRouter struct {
-	bookController   *handler.BookHandler
-	authorController *handler.AuthorHandler
-	borrowController *handler.BorrowHandler
-	swaggerRouter    *SwaggerRouter
+	bookController        *handler.BookHandler
+	authorController      *handler.AuthorHandler
+	publisherController   *handler.PublisherHandler
+	borrowController      *handler.BorrowHandler
+	copyController        *handler.BookCopyHandler
+	reservationController *handler.ReservationHandler
+	fineController        *handler.FineHandler
+	memberController      *handler.MemberHandler
+	loanPolicyController  *handler.LoanPolicyHandler
+	genreController       *handler.GenreHandler
+	tagController         *handler.TagHandler
+	branchController      *handler.BranchHandler
+	transferController    *handler.TransferHandler
+	coverController       *handler.BookCoverHandler
+	searchController      *handler.SearchHandler
+	swaggerRouter         *SwaggerRouter
 }
 
 func NewAppRouter(
 	bookController *handler.BookHandler,
 	authorController *handler.AuthorHandler,
+	publisherController *handler.PublisherHandler,
 	borrowController *handler.BorrowHandler,
+	copyController *handler.BookCopyHandler,
+	reservationController *handler.ReservationHandler,
+	fineController *handler.FineHandler,
+	memberController *handler.MemberHandler,
+	loanPolicyController *handler.LoanPolicyHandler,
+	genreController *handler.GenreHandler,
+	tagController *handler.TagHandler,
+	branchController *handler.BranchHandler,
+	transferController *handler.TransferHandler,
+	coverController *handler.BookCoverHandler,
+	searchController *handler.SearchHandler,
 	swaggerRouter *SwaggerRouter,
 ) *AppRouter {
 	return &AppRouter{
-		bookController:   bookController,
-		authorController: authorController,
-		borrowController: borrowController,
-		swaggerRouter:    swaggerRouter,
+		bookController:        bookController,
+		authorController:      authorController,
+		publisherController:   publisherController,
+		borrowController:      borrowController,
+		copyController:        copyController,
+		reservationController: reservationController,
+		fineController:        fineController,
+		memberController:      memberController,
+		loanPolicyController:  loanPolicyController,
+		genreController:       genreController,
+		tagController:         tagController,
+		branchController:      branchController,
+		transferController:    transferController,
+		coverController:       coverController,
+		searchController:      searchController,
+		swaggerRouter:         swaggerRouter,
 	}
 }
 
@@ -31,13 +67,30 @@ func (a *AppRouter) RegisterBookRoutes(r *gin.RouterGroup) {
 	public := r.Group("/books")
 	{
 		public.GET("", a.bookController.ListBooks)
+		public.GET("/aggregate", a.bookController.AggregateBooks)
 		public.GET("/:id", a.bookController.GetBook)
+		public.GET("/isbn/:isbn", a.bookController.GetBookByISBN)
 		public.POST("", a.bookController.CreateBook)
 		public.PUT("/:id", a.bookController.UpdateBook)
 		public.DELETE("/:id", a.bookController.DeleteBook)
+		public.GET("/:id/copies", a.copyController.ListBookCopies)
+		public.POST("/:id/copies", a.copyController.CreateBookCopy)
+		public.GET("/:id/availability", a.copyController.GetBookAvailability)
+		public.GET("/:id/reservations", a.reservationController.ListBookReservations)
+		public.POST("/:id/reservations", a.reservationController.CreateReservation)
+		public.POST("/:id/genres/:genre_id", a.genreController.AddBookGenre)
+		public.DELETE("/:id/genres/:genre_id", a.genreController.RemoveBookGenre)
+		public.POST("/:id/tags", a.tagController.AddBookTag)
+		public.DELETE("/:id/tags/:tag_id", a.tagController.RemoveBookTag)
+		public.GET("/:id/cover", a.coverController.GetCover)
+		public.PUT("/:id/cover", a.coverController.UploadCover)
 	}
 }
 
+func (a *AppRouter) RegisterSearchRoutes(r *gin.RouterGroup) {
+	r.GET("/search", a.searchController.Search)
+}
+
 func (a *AppRouter) RegisterAuthorRoutes(r *gin.RouterGroup) {
 	public := r.Group("/authors")
 	{
@@ -49,14 +102,126 @@ func (a *AppRouter) RegisterAuthorRoutes(r *gin.RouterGroup) {
 	}
 }
 
+func (a *AppRouter) RegisterPublisherRoutes(r *gin.RouterGroup) {
+	public := r.Group("/publishers")
+	{
+		public.GET("", a.publisherController.ListPublishers)
+		public.GET("/:id", a.publisherController.GetPublisher)
+		public.POST("", a.publisherController.CreatePublisher)
+		public.PUT("/:id", a.publisherController.UpdatePublisher)
+		public.DELETE("/:id", a.publisherController.DeletePublisher)
+	}
+}
+
+func (a *AppRouter) RegisterGenreRoutes(r *gin.RouterGroup) {
+	public := r.Group("/genres")
+	{
+		public.GET("", a.genreController.ListGenres)
+		public.GET("/:id", a.genreController.GetGenre)
+		public.POST("", a.genreController.CreateGenre)
+		public.PUT("/:id", a.genreController.UpdateGenre)
+		public.DELETE("/:id", a.genreController.DeleteGenre)
+	}
+}
+
+func (a *AppRouter) RegisterTagRoutes(r *gin.RouterGroup) {
+	public := r.Group("/tags")
+	{
+		public.GET("", a.tagController.ListTags)
+		public.GET("/:id", a.tagController.GetTag)
+		public.POST("", a.tagController.CreateTag)
+		public.PUT("/:id", a.tagController.UpdateTag)
+		public.DELETE("/:id", a.tagController.DeleteTag)
+	}
+}
+
+func (a *AppRouter) RegisterBranchRoutes(r *gin.RouterGroup) {
+	public := r.Group("/branches")
+	{
+		public.GET("", a.branchController.ListBranches)
+		public.GET("/:id", a.branchController.GetBranch)
+		public.POST("", a.branchController.CreateBranch)
+		public.PUT("/:id", a.branchController.UpdateBranch)
+		public.DELETE("/:id", a.branchController.DeleteBranch)
+	}
+}
+
+func (a *AppRouter) RegisterTransferRoutes(r *gin.RouterGroup) {
+	public := r.Group("/transfers")
+	{
+		public.GET("", a.transferController.ListTransfers)
+		public.GET("/:id", a.transferController.GetTransfer)
+		public.GET("/:id/events", a.transferController.ListTransferEvents)
+		public.POST("", a.transferController.CreateTransfer)
+		public.POST("/:id/ship", a.transferController.ShipTransfer)
+		public.POST("/:id/receive", a.transferController.ReceiveTransfer)
+		public.POST("/:id/cancel", a.transferController.CancelTransfer)
+	}
+}
+
+func (a *AppRouter) RegisterMemberRoutes(r *gin.RouterGroup) {
+	public := r.Group("/members")
+	{
+		public.GET("", a.memberController.ListMembers)
+		public.GET("/:id", a.memberController.GetMember)
+		public.POST("", a.memberController.CreateMember)
+		public.PUT("/:id", a.memberController.UpdateMember)
+		public.DELETE("/:id", a.memberController.DeleteMember)
+		public.GET("/:id/borrows", a.borrowController.ListMemberBorrows)
+	}
+}
+
+func (a *AppRouter) RegisterLoanPolicyRoutes(r *gin.RouterGroup) {
+	public := r.Group("/loan-policies")
+	{
+		public.GET("", a.loanPolicyController.ListLoanPolicies)
+		public.GET("/:id", a.loanPolicyController.GetLoanPolicy)
+		public.POST("", a.loanPolicyController.CreateLoanPolicy)
+		public.PUT("/:id", a.loanPolicyController.UpdateLoanPolicy)
+		public.DELETE("/:id", a.loanPolicyController.DeleteLoanPolicy)
+	}
+}
+
 func (a *AppRouter) RegisterBorrowRoutes(r *gin.RouterGroup) {
 	public := r.Group("/borrows")
 	{
 		public.GET("", a.borrowController.ListBorrows)
+		public.GET("/aggregate", a.borrowController.AggregateBorrows)
 		public.GET("/:id", a.borrowController.GetBorrow)
 		public.POST("", a.borrowController.CreateBorrow)
 		public.PUT("/:id", a.borrowController.UpdateBorrow)
 		public.DELETE("/:id", a.borrowController.DeleteBorrow)
+		public.POST("/:id/return", a.borrowController.ReturnBorrow)
+		public.POST("/:id/renew", a.borrowController.RenewBorrow)
+		public.GET("/:id/renewals", a.borrowController.ListRenewals)
+	}
+}
+
+func (a *AppRouter) RegisterCopyRoutes(r *gin.RouterGroup) {
+	public := r.Group("/copies")
+	{
+		public.GET("/:id", a.copyController.GetCopy)
+		public.PUT("/:id", a.copyController.UpdateCopy)
+		public.DELETE("/:id", a.copyController.DeleteCopy)
+	}
+}
+
+func (a *AppRouter) RegisterReservationRoutes(r *gin.RouterGroup) {
+	public := r.Group("/reservations")
+	{
+		public.GET("/:id", a.reservationController.GetReservation)
+		public.DELETE("/:id", a.reservationController.CancelReservation)
+	}
+}
+
+func (a *AppRouter) RegisterFineRoutes(r *gin.RouterGroup) {
+	public := r.Group("/fines")
+	{
+		public.GET("", a.fineController.ListFines)
+		public.GET("/balances", a.fineController.ListOutstandingBalances)
+		public.GET("/balances/:member_id", a.fineController.GetBalance)
+		public.POST("/payments", a.fineController.RecordPayment)
+		public.POST("/waivers", a.fineController.WaiveFine)
 	}
 }
 
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"borrow_book/internal/config"
 	"borrow_book/internal/domain/model"
 	"borrow_book/internal/infra/database/query"
 	"borrow_book/internal/repository"
@@ -10,7 +11,7 @@ import (
 
 // AuthorService defines the interface for author-related operations
 type AuthorService interface {
-	ListAuthors(ctx context.Context, filters, sorts []string, fields string) ([]model.Author, error)
+	ListAuthors(ctx context.Context, filters, sorts []string, fields, q string, page query.PageRequest) ([]model.Author, query.PageInfo, error)
 	GetAuthor(ctx context.Context, id int) (*model.Author, error)
 	CreateAuthor(ctx context.Context, name string) (*model.Author, error)
 	UpdateAuthor(ctx context.Context, id int, name string) (*model.Author, error)
@@ -19,31 +20,60 @@ type AuthorService interface {
 
 // authorService is the concrete implementation of AuthorService
 type authorService struct {
-	repo repository.AuthorRepository
+	repo   repository.AuthorRepository
+	paging config.PagingConfig
 }
 
 // NewAuthorService creates a new instance of AuthorService
-func NewAuthorService(repo repository.AuthorRepository) AuthorService {
-	return &authorService{repo: repo}
+func NewAuthorService(repo repository.AuthorRepository, cfg *config.Config) AuthorService {
+	return &authorService{repo: repo, paging: cfg.Paging}
 }
 
-func (s *authorService) ListAuthors(ctx context.Context, filters, sorts []string, fields string) ([]model.Author, error) {
-	f, err := query.ParseFilters(filters)
+func (s *authorService) ListAuthors(ctx context.Context, filters, sorts []string, fields, q string, page query.PageRequest) ([]model.Author, query.PageInfo, error) {
+	f, err := query.ParseFilters(repository.AuthorSchema, filters)
 	if err != nil {
-		return nil, err
+		return nil, query.PageInfo{}, err
 	}
-	srts, err := query.ParseSorts(sorts)
+	where, err := query.ParseExpr(repository.AuthorSchema, q)
 	if err != nil {
-		return nil, err
+		return nil, query.PageInfo{}, err
+	}
+	srts, err := query.ParseSorts(repository.AuthorSchema, sorts)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	fs, err := query.ParseFields(repository.AuthorSchema, fields)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	p, err := query.NewPage(page, srts, s.paging.DefaultPageSize, s.paging.MaxPageSize)
+	if err != nil {
+		return nil, query.PageInfo{}, err
 	}
-	fs := query.ParseFields(fields)
 
 	opts := query.QueryOptions{
 		Filters: f,
+		Where:   where,
 		Sorts:   srts,
 		Fields:  fs,
+		Page:    p,
+	}
+	authors, err := s.repo.GetAllAuthors(ctx, opts)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	authors, info, err := query.Paginate(authors, opts)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	if p.WithTotal {
+		total, err := s.repo.CountAuthors(ctx, opts)
+		if err != nil {
+			return nil, query.PageInfo{}, err
+		}
+		info.Total = &total
 	}
-	return s.repo.GetAllAuthors(ctx, opts)
+	return authors, info, nil
 }
 
 func (s *authorService) GetAuthor(ctx context.Context, id int) (*model.Author, error) {
@@ -72,9 +102,10 @@ func (s *authorService) UpdateAuthor(ctx context.Context, id int, name string) (
 		return nil, fmt.Errorf("author not found")
 	}
 
+	old := *a
 	a.Name = name
 
-	err = s.repo.UpdateAuthor(ctx, *a)
+	err = s.repo.UpdateAuthor(ctx, old, *a)
 	if err != nil {
 		return nil, err
 	}
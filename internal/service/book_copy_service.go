@@ -0,0 +1,200 @@
+package service
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// BookCopyService defines the interface for physical copy operations
+type BookCopyService interface {
+	ListBookCopies(ctx context.Context, bookID int) ([]model.BookCopy, error)
+	GetCopy(ctx context.Context, id int) (*model.BookCopy, error)
+	CreateCopy(ctx context.Context, bookID, branchID int, barcode string, acquiredAt int64) (*model.BookCopy, error)
+	UpdateCopy(ctx context.Context, id int, barcode, status string, acquiredAt int64) (*model.BookCopy, error)
+	DeleteCopy(ctx context.Context, id int) error
+	// GetAvailability counts the copies of a book at a branch, or at every branch when branchID is 0.
+	GetAvailability(ctx context.Context, bookID, branchID int) (*model.BookAvailability, error)
+}
+
+// bookCopyService is the concrete implementation of BookCopyService
+type bookCopyService struct {
+	repo           repository.BookCopyRepository
+	bookRepo       repository.BookRepository
+	reservationSvc ReservationService
+	branchSvc      BranchService
+}
+
+// NewBookCopyService creates a new instance of BookCopyService
+func NewBookCopyService(
+	repo repository.BookCopyRepository,
+	bookRepo repository.BookRepository,
+	reservationSvc ReservationService,
+	branchSvc BranchService,
+) BookCopyService {
+	return &bookCopyService{repo: repo, bookRepo: bookRepo, reservationSvc: reservationSvc, branchSvc: branchSvc}
+}
+
+func (s *bookCopyService) ListBookCopies(ctx context.Context, bookID int) ([]model.BookCopy, error) {
+	if err := s.ensureBookExists(ctx, bookID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetCopiesByBookID(ctx, bookID)
+}
+
+func (s *bookCopyService) GetCopy(ctx context.Context, id int) (*model.BookCopy, error) {
+	return s.repo.GetCopyByID(ctx, id)
+}
+
+func (s *bookCopyService) CreateCopy(ctx context.Context, bookID, branchID int, barcode string, acquiredAt int64) (*model.BookCopy, error) {
+	if err := s.ensureBookExists(ctx, bookID); err != nil {
+		return nil, err
+	}
+	if err := s.branchSvc.EnsureBranchExists(ctx, branchID); err != nil {
+		return nil, err
+	}
+	if err := s.ensureBarcodeFree(ctx, barcode, 0); err != nil {
+		return nil, err
+	}
+
+	newCopy := model.BookCopy{
+		BookID:     bookID,
+		BranchID:   branchID,
+		Barcode:    barcode,
+		Status:     model.CopyStatusAvailable,
+		AcquiredAt: acquiredAt,
+	}
+	id, err := s.repo.CreateCopy(ctx, newCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Patrons waiting for the book get the new copy first
+	err = s.reservationSvc.ReleaseCopy(ctx, bookID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetCopyByID(ctx, id)
+}
+
+func (s *bookCopyService) UpdateCopy(ctx context.Context, id int, barcode, status string, acquiredAt int64) (*model.BookCopy, error) {
+	c, err := s.GetCopy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrCopyNotFound
+	}
+
+	// Loans, reservations and transfers are the only way in and out of the
+	// on_loan, on_hold and in_transit statuses
+	statusChanged := status != c.Status
+	if statusChanged {
+		if err := checkCopyIdle(c); err != nil {
+			return nil, err
+		}
+		if !isManualCopyStatus(status) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCopyStatus, status)
+		}
+	}
+	if err := s.ensureBarcodeFree(ctx, barcode, id); err != nil {
+		return nil, err
+	}
+
+	c.Barcode = barcode
+	c.Status = status
+	c.AcquiredAt = acquiredAt
+
+	err = s.repo.UpdateCopy(ctx, *c)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusChanged && status == model.CopyStatusAvailable {
+		// A copy back from maintenance serves the queue of the book first
+		err = s.reservationSvc.ReleaseCopy(ctx, c.BookID, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		return s.repo.GetCopyByID(ctx, c.ID)
+	}
+	return c, nil
+}
+
+func (s *bookCopyService) DeleteCopy(ctx context.Context, id int) error {
+	c, err := s.GetCopy(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return ErrCopyNotFound
+	}
+	if err := checkCopyIdle(c); err != nil {
+		return err
+	}
+	err = s.repo.DeleteCopy(ctx, id)
+	if errors.Is(err, repository.ErrReferenced) {
+		return ErrCopyHasBorrows
+	}
+	return err
+}
+
+func (s *bookCopyService) GetAvailability(ctx context.Context, bookID, branchID int) (*model.BookAvailability, error) {
+	if err := s.ensureBookExists(ctx, bookID); err != nil {
+		return nil, err
+	}
+	if branchID != 0 {
+		if err := s.branchSvc.EnsureBranchExists(ctx, branchID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.GetAvailability(ctx, bookID, branchID)
+}
+
+func (s *bookCopyService) ensureBookExists(ctx context.Context, bookID int) error {
+	b, err := s.bookRepo.GetBookByID(ctx, bookID)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return ErrBookNotFound
+	}
+	return nil
+}
+
+// ensureBarcodeFree checks that no copy other than exceptID uses the barcode.
+func (s *bookCopyService) ensureBarcodeFree(ctx context.Context, barcode string, exceptID int) error {
+	existing, err := s.repo.GetCopyByBarcode(ctx, barcode)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != exceptID {
+		return ErrDuplicateBarcode
+	}
+	return nil
+}
+
+// checkCopyIdle returns the reason a copy cannot be changed by hand, or nil when
+// it is not on loan, on hold or in transit.
+func checkCopyIdle(c *model.BookCopy) error {
+	switch c.Status {
+	case model.CopyStatusOnLoan:
+		return ErrCopyOnLoan
+	case model.CopyStatusOnHold:
+		return ErrCopyOnHold
+	case model.CopyStatusInTransit:
+		return ErrCopyInTransit
+	}
+	return nil
+}
+
+// isManualCopyStatus reports whether a copy can be put in the status by hand.
+func isManualCopyStatus(status string) bool {
+	switch status {
+	case model.CopyStatusAvailable, model.CopyStatusMaintenance, model.CopyStatusLost:
+		return true
+	}
+	return false
+}
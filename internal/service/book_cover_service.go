@@ -0,0 +1,191 @@
+package service
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/storage"
+	"borrow_book/internal/repository"
+	"borrow_book/pkg/thumbnail"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"time"
+)
+
+// maxCoverPixels bounds the memory needed to decode an uploaded cover.
+const maxCoverPixels = 40_000_000
+
+// coverThumbnails holds the bounding box of each generated thumbnail size.
+var coverThumbnails = map[string]image.Point{
+	model.CoverSizeLarge:  {X: 600, Y: 900},
+	model.CoverSizeMedium: {X: 300, Y: 450},
+	model.CoverSizeSmall:  {X: 120, Y: 180},
+}
+
+// BookCoverService defines the interface for book cover operations
+type BookCoverService interface {
+	// UploadCover replaces the cover of a book with a JPEG or PNG image and generates its thumbnails.
+	UploadCover(ctx context.Context, bookID int, data []byte) (*model.BookCover, error)
+	// GetCover opens the cover of a book in the given size. The caller must close the Body of the object.
+	GetCover(ctx context.Context, bookID int, size string) (*model.BookCover, *storage.Object, error)
+}
+
+// bookCoverService is the concrete implementation of BookCoverService
+type bookCoverService struct {
+	repo     repository.BookCoverRepository
+	bookRepo repository.BookRepository
+	store    storage.Storage
+	maxSize  int64
+}
+
+// NewBookCoverService creates a new instance of BookCoverService
+func NewBookCoverService(
+	repo repository.BookCoverRepository,
+	bookRepo repository.BookRepository,
+	store storage.Storage,
+	cfg *config.Config,
+) BookCoverService {
+	return &bookCoverService{repo: repo, bookRepo: bookRepo, store: store, maxSize: cfg.Storage.MaxCoverSize}
+}
+
+func (s *bookCoverService) UploadCover(ctx context.Context, bookID int, data []byte) (*model.BookCover, error) {
+	if err := s.ensureBookExists(ctx, bookID); err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrCoverTooLarge, s.maxSize)
+	}
+
+	// Trust the content of the file rather than the type announced by the client
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCoverType, contentType)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCoverImage, err)
+	}
+	if cfg.Width*cfg.Height > maxCoverPixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels is too large", ErrInvalidCoverImage, cfg.Width, cfg.Height)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCoverImage, err)
+	}
+
+	existing, err := s.repo.GetCoverByBookID(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Put(ctx, coverKey(bookID, model.CoverSizeOriginal, contentType), contentType, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	for size, box := range coverThumbnails {
+		var buf bytes.Buffer
+		if err := encodeCover(&buf, thumbnail.Fit(img, box.X, box.Y), contentType); err != nil {
+			return nil, err
+		}
+		if err := s.store.Put(ctx, coverKey(bookID, size, contentType), contentType, &buf); err != nil {
+			return nil, err
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	cover := model.BookCover{
+		BookID:      bookID,
+		ContentType: contentType,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		Size:        int64(len(data)),
+		Checksum:    hex.EncodeToString(sum[:]),
+		UpdatedAt:   time.Now().Unix(),
+	}
+	if err := s.repo.SaveCover(ctx, cover); err != nil {
+		return nil, err
+	}
+
+	// Files of a previous cover in another format are not overwritten
+	if existing != nil && existing.ContentType != contentType {
+		for _, size := range coverSizes() {
+			if err := s.store.Delete(ctx, coverKey(bookID, size, existing.ContentType)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &cover, nil
+}
+
+func (s *bookCoverService) GetCover(ctx context.Context, bookID int, size string) (*model.BookCover, *storage.Object, error) {
+	if size == "" {
+		size = model.CoverSizeOriginal
+	}
+	if _, ok := coverThumbnails[size]; !ok && size != model.CoverSizeOriginal {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidCoverSize, size)
+	}
+	if err := s.ensureBookExists(ctx, bookID); err != nil {
+		return nil, nil, err
+	}
+
+	cover, err := s.repo.GetCoverByBookID(ctx, bookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cover == nil {
+		return nil, nil, ErrCoverNotFound
+	}
+
+	obj, err := s.store.Get(ctx, coverKey(bookID, size, cover.ContentType))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, ErrCoverNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return cover, obj, nil
+}
+
+func (s *bookCoverService) ensureBookExists(ctx context.Context, bookID int) error {
+	b, err := s.bookRepo.GetBookByID(ctx, bookID)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return ErrBookNotFound
+	}
+	return nil
+}
+
+// coverKey returns the storage key of a cover size, e.g. "covers/42/small.jpg".
+func coverKey(bookID int, size, contentType string) string {
+	ext := ".jpg"
+	if contentType == "image/png" {
+		ext = ".png"
+	}
+	return fmt.Sprintf("covers/%d/%s%s", bookID, size, ext)
+}
+
+// coverSizes lists every size a cover is stored in.
+func coverSizes() []string {
+	sizes := []string{model.CoverSizeOriginal}
+	for size := range coverThumbnails {
+		sizes = append(sizes, size)
+	}
+	return sizes
+}
+
+// encodeCover encodes a thumbnail in the format of the original cover.
+func encodeCover(buf *bytes.Buffer, img image.Image, contentType string) error {
+	if contentType == "image/png" {
+		return png.Encode(buf, img)
+	}
+	return jpeg.Encode(buf, img, &jpeg.Options{Quality: 85})
+}
@@ -1,84 +1,352 @@
 package service
 
 import (
+	"borrow_book/internal/config"
 	"borrow_book/internal/domain/model"
 	"borrow_book/internal/infra/database/query"
 	"borrow_book/internal/repository"
+	"borrow_book/pkg/isbn"
 	"context"
 	"fmt"
+	"strings"
 )
 
 type BookService interface {
-	ListBooks(ctx context.Context, filters, sorts []string, fields string) ([]model.Book, error)
+	ListBooks(ctx context.Context, filters, sorts []string, fields, q, expand string, page query.PageRequest) ([]model.Book, query.PageInfo, error)
+	// AggregateBooks counts the books matching the filters, or aggregates their
+	// columns, by the groups of groupBy.
+	AggregateBooks(ctx context.Context, filters []string, q, groupBy, agg string) ([]map[string]interface{}, error)
 	GetBook(ctx context.Context, id int) (*model.Book, error)
-	CreateBook(ctx context.Context, title string, authorID int, publishedAt int64) (*model.Book, error)
-	UpdateBook(ctx context.Context, id int, title string, authorID int, publishedAt int64) (*model.Book, error)
+	// ExpandBooks embeds the relations listed in expand, among BookExpansions, in the books.
+	ExpandBooks(ctx context.Context, books []model.Book, expand string) error
+	// GetBookByISBN looks a book up by its ISBN-10 or ISBN-13.
+	GetBookByISBN(ctx context.Context, raw string) (*model.Book, error)
+	CreateBook(ctx context.Context, b model.Book) (*model.Book, error)
+	UpdateBook(ctx context.Context, b model.Book) (*model.Book, error)
 	DeleteBook(ctx context.Context, id int) error
 }
 
+// BookExpansions are the relations that can be embedded in books.
+var BookExpansions = []string{"author", "publisher"}
+
 type bookService struct {
-	repo repository.BookRepository
+	repo          repository.BookRepository
+	authorRepo    repository.AuthorRepository
+	publisherRepo repository.PublisherRepository
+	paging        config.PagingConfig
 }
 
-func NewBookService(repo repository.BookRepository) BookService {
-	return &bookService{repo: repo}
+func NewBookService(
+	repo repository.BookRepository,
+	authorRepo repository.AuthorRepository,
+	publisherRepo repository.PublisherRepository,
+	cfg *config.Config,
+) BookService {
+	return &bookService{repo: repo, authorRepo: authorRepo, publisherRepo: publisherRepo, paging: cfg.Paging}
 }
 
-func (s *bookService) ListBooks(ctx context.Context, filters, sorts []string, fields string) ([]model.Book, error) {
-	f, err := query.ParseFilters(filters)
+func (s *bookService) ListBooks(ctx context.Context, filters, sorts []string, fields, q, expand string, page query.PageRequest) ([]model.Book, query.PageInfo, error) {
+	e, err := query.ParseExpand(expand, BookExpansions...)
 	if err != nil {
-		return nil, err
+		return nil, query.PageInfo{}, err
 	}
-	srts, err := query.ParseSorts(sorts)
+	f, err := query.ParseFilters(repository.BookSchema, filters)
 	if err != nil {
-		return nil, err
+		return nil, query.PageInfo{}, err
+	}
+	where, err := query.ParseExpr(repository.BookSchema, q)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	srts, err := query.ParseSorts(repository.BookSchema, sorts)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	fs, err := query.ParseFields(repository.BookSchema, fields)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	if e.Has("author") {
+		fs = query.RequireFields(fs, "id") // Contributors are loaded by book id
+	}
+	if e.Has("publisher") {
+		fs = query.RequireFields(fs, "publisher_id")
+	}
+	p, err := query.NewPage(page, srts, s.paging.DefaultPageSize, s.paging.MaxPageSize)
+	if err != nil {
+		return nil, query.PageInfo{}, err
 	}
-	fs := query.ParseFields(fields)
 
 	opts := query.QueryOptions{
 		Filters: f,
+		Where:   where,
 		Sorts:   srts,
 		Fields:  fs,
+		Page:    p,
+	}
+	books, err := s.repo.GetAllBooks(ctx, opts)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	books, info, err := query.Paginate(books, opts)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	if p.WithTotal {
+		total, err := s.repo.CountBooks(ctx, opts)
+		if err != nil {
+			return nil, query.PageInfo{}, err
+		}
+		info.Total = &total
+	}
+	if err := s.expandBooks(ctx, books, e); err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	return books, info, nil
+}
+
+func (s *bookService) AggregateBooks(ctx context.Context, filters []string, q, groupBy, agg string) ([]map[string]interface{}, error) {
+	f, err := query.ParseFilters(repository.BookSchema, filters)
+	if err != nil {
+		return nil, err
+	}
+	where, err := query.ParseExpr(repository.BookSchema, q)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := query.ParseGroupBy(repository.BookSchema, groupBy)
+	if err != nil {
+		return nil, err
+	}
+	aggs, err := query.ParseAggregates(repository.BookSchema, agg)
+	if err != nil {
+		return nil, err
 	}
-	return s.repo.GetAllBooks(ctx, opts)
+
+	opts := query.QueryOptions{
+		Filters:    f,
+		Where:      where,
+		GroupBy:    groups,
+		Aggregates: aggs,
+	}
+	return s.repo.AggregateBooks(ctx, opts)
 }
 
 func (s *bookService) GetBook(ctx context.Context, id int) (*model.Book, error) {
 	return s.repo.GetBookByID(ctx, id)
 }
 
-func (s *bookService) CreateBook(ctx context.Context, title string, authorID int, publishedAt int64) (*model.Book, error) {
-	newBook := model.Book{
-		Title:       title,
-		AuthorID:    authorID,
-		PublishedAt: publishedAt,
+func (s *bookService) ExpandBooks(ctx context.Context, books []model.Book, expand string) error {
+	e, err := query.ParseExpand(expand, BookExpansions...)
+	if err != nil {
+		return err
+	}
+	return s.expandBooks(ctx, books, e)
+}
+
+// expandBooks loads each expanded relation of all the books with a single query.
+func (s *bookService) expandBooks(ctx context.Context, books []model.Book, e query.Expand) error {
+	if len(books) == 0 {
+		return nil
 	}
-	id, err := s.repo.CreateBook(ctx, newBook)
+	if e.Has("author") {
+		var ids []int
+		for _, b := range books {
+			for _, c := range b.Contributors {
+				ids = append(ids, c.AuthorID)
+			}
+		}
+		authors, err := s.authorRepo.GetAuthorsByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		byID := make(map[int]model.Author, len(authors))
+		for _, a := range authors {
+			byID[a.ID] = a
+		}
+		for i := range books {
+			// Contributors come in order and may list an author in several roles
+			books[i].Authors = []model.Author{}
+			seen := make(map[int]bool)
+			for _, c := range books[i].Contributors {
+				if a, ok := byID[c.AuthorID]; ok && !seen[a.ID] {
+					seen[a.ID] = true
+					books[i].Authors = append(books[i].Authors, a)
+				}
+			}
+		}
+	}
+
+	if e.Has("publisher") {
+		var ids []int
+		for _, b := range books {
+			if b.PublisherID != nil {
+				ids = append(ids, *b.PublisherID)
+			}
+		}
+		publishers, err := s.publisherRepo.GetPublishersByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		byID := make(map[int]*model.Publisher, len(publishers))
+		for i := range publishers {
+			byID[publishers[i].ID] = &publishers[i]
+		}
+		for i := range books {
+			if books[i].PublisherID != nil {
+				books[i].Publisher = byID[*books[i].PublisherID]
+			}
+		}
+	}
+	return nil
+}
+
+func (s *bookService) GetBookByISBN(ctx context.Context, raw string) (*model.Book, error) {
+	isbn13, err := isbn.Normalize(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidISBN, err)
+	}
+	return s.repo.GetBookByISBN(ctx, isbn13)
+}
+
+func (s *bookService) CreateBook(ctx context.Context, b model.Book) (*model.Book, error) {
+	if err := s.prepareISBN(ctx, &b); err != nil {
+		return nil, err
+	}
+	if err := s.prepareContributors(ctx, &b); err != nil {
+		return nil, err
+	}
+	if err := s.prepareEdition(ctx, &b); err != nil {
+		return nil, err
+	}
+	id, err := s.repo.CreateBook(ctx, b)
 	if err != nil {
 		return nil, err
 	}
-	newBook.ID = id
-	return &newBook, nil
+	return s.GetBook(ctx, id)
 }
 
-func (s *bookService) UpdateBook(ctx context.Context, id int, title string, authorID int, publishedAt int64) (*model.Book, error) {
-	b, err := s.GetBook(ctx, id)
+func (s *bookService) UpdateBook(ctx context.Context, b model.Book) (*model.Book, error) {
+	existing, err := s.GetBook(ctx, b.ID)
 	if err != nil {
 		return nil, err
 	}
-	if b == nil {
-		return nil, fmt.Errorf("book not found")
+	if existing == nil {
+		return nil, ErrBookNotFound
+	}
+	if err := s.prepareISBN(ctx, &b); err != nil {
+		return nil, err
+	}
+	if err := s.prepareContributors(ctx, &b); err != nil {
+		return nil, err
+	}
+	if err := s.prepareEdition(ctx, &b); err != nil {
+		return nil, err
 	}
 
-	b.Title = title
-	b.AuthorID = authorID
-	b.PublishedAt = publishedAt
-
-	err = s.repo.UpdateBook(ctx, *b)
+	err = s.repo.UpdateBook(ctx, *existing, b)
 	if err != nil {
 		return nil, err
 	}
-	return b, nil
+	return s.GetBook(ctx, b.ID)
+}
+
+// prepareISBN normalizes the ISBN of a book, given in ISBN13 as either form,
+// and checks that no other book uses it.
+func (s *bookService) prepareISBN(ctx context.Context, b *model.Book) error {
+	if b.ISBN13 == "" {
+		b.ISBN10 = ""
+		return nil
+	}
+	isbn13, err := isbn.Normalize(b.ISBN13)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidISBN, err)
+	}
+	b.ISBN13 = isbn13
+	b.ISBN10 = isbn.To10(isbn13)
+
+	existing, err := s.repo.GetBookByISBN(ctx, isbn13)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != b.ID {
+		return fmt.Errorf("%w: book %d", ErrDuplicateISBN, existing.ID)
+	}
+	return nil
+}
+
+// prepareContributors checks the contributors of a book and numbers them in the given order.
+// Contributors without a role are authors.
+func (s *bookService) prepareContributors(ctx context.Context, b *model.Book) error {
+	seen := make(map[model.BookContributor]bool)
+	for i := range b.Contributors {
+		c := &b.Contributors[i]
+		switch c.Role {
+		case "":
+			c.Role = model.ContributorRoleAuthor
+		case model.ContributorRoleAuthor, model.ContributorRoleEditor,
+			model.ContributorRoleTranslator, model.ContributorRoleIllustrator:
+		default:
+			return fmt.Errorf("%w: %s", ErrInvalidContributorRole, c.Role)
+		}
+
+		key := model.BookContributor{AuthorID: c.AuthorID, Role: c.Role}
+		if seen[key] {
+			return fmt.Errorf("%w: author %d as %s", ErrDuplicateContributor, c.AuthorID, c.Role)
+		}
+		seen[key] = true
+
+		author, err := s.authorRepo.GetAuthorByID(ctx, c.AuthorID)
+		if err != nil {
+			return err
+		}
+		if author == nil {
+			return fmt.Errorf("%w: %d", ErrAuthorNotFound, c.AuthorID)
+		}
+		c.Position = i + 1
+	}
+	return nil
+}
+
+// prepareEdition checks the publisher of a book and normalizes its language and format.
+func (s *bookService) prepareEdition(ctx context.Context, b *model.Book) error {
+	if b.PublisherID != nil {
+		p, err := s.publisherRepo.GetPublisherByID(ctx, *b.PublisherID)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return fmt.Errorf("%w: %d", ErrPublisherNotFound, *b.PublisherID)
+		}
+	}
+
+	b.Format = strings.ToLower(strings.TrimSpace(b.Format))
+	switch b.Format {
+	case "", model.BookFormatHardcover, model.BookFormatPaperback,
+		model.BookFormatEbook, model.BookFormatAudiobook:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidBookFormat, b.Format)
+	}
+
+	b.Language = strings.ToLower(strings.TrimSpace(b.Language))
+	if b.Language != "" && !isLanguageCode(b.Language) {
+		return fmt.Errorf("%w: %s", ErrInvalidLanguage, b.Language)
+	}
+	b.Edition = strings.TrimSpace(b.Edition)
+	return nil
+}
+
+// isLanguageCode reports whether code looks like an ISO 639-1 or 639-3 code.
+func isLanguageCode(code string) bool {
+	if len(code) != 2 && len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
 }
 
 func (s *bookService) DeleteBook(ctx context.Context, id int) error {
@@ -1,81 +1,441 @@
 package service
 
 import (
+	"borrow_book/internal/config"
 	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/postgres"
 	"borrow_book/internal/infra/database/query"
 	"borrow_book/internal/repository"
 	"context"
+	"errors"
 	"fmt"
+	"time"
 )
 
 type BorrowService interface {
-	ListBorrowLists(ctx context.Context, filters, sorts []string, fields string) ([]model.Borrow, error)
+	ListBorrowLists(ctx context.Context, filters, sorts []string, fields, status, q, expand string, page query.PageRequest) ([]model.Borrow, query.PageInfo, error)
+	// AggregateBorrows counts the borrows matching the filters and status, or
+	// aggregates their columns, by the groups of groupBy.
+	AggregateBorrows(ctx context.Context, filters []string, status, q, groupBy, agg string) ([]map[string]interface{}, error)
 	GetBorrow(ctx context.Context, id int) (*model.Borrow, error)
-	CreateBorrow(ctx context.Context, bookID int, userName string, borrowedAt int64) (*model.Borrow, error)
-	UpdateBorrow(ctx context.Context, id int, bookID int, userName string, borrowedAt int64) (*model.Borrow, error)
+	// ExpandBorrows embeds the relations listed in expand, among BorrowExpansions, in the borrows.
+	ExpandBorrows(ctx context.Context, borrows []model.Borrow, expand string) error
+	// CreateBorrow lends a copy of the book, at the given branch unless branchID is 0.
+	CreateBorrow(ctx context.Context, bookID, copyID, branchID, memberID int, borrowedAt int64) (*model.Borrow, error)
+	// UpdateBorrow corrects a loan, moving its due date along with its borrow date.
+	UpdateBorrow(ctx context.Context, id, bookID, memberID int, borrowedAt int64) (*model.Borrow, error)
 	DeleteBorrow(ctx context.Context, id int) error
+	// ReturnBorrow closes a borrow at the given branch, or at the branch that lent the copy when branchID is 0.
+	ReturnBorrow(ctx context.Context, id, branchID int) (*model.Borrow, error)
+	RenewBorrow(ctx context.Context, id int) (*model.Borrow, error)
+	ListRenewals(ctx context.Context, id int) ([]model.BorrowRenewal, error)
+	// ListMemberBorrows returns a page of the loans of a member and the number of loans over all pages.
+	ListMemberBorrows(ctx context.Context, memberID int, loans string, page, pageSize int) ([]model.BorrowHistoryEntry, int, error)
 }
 
+// BorrowExpansions are the relations that can be embedded in borrows.
+var BorrowExpansions = []string{"book", "book.author", "book.publisher", "member"}
+
 type borrowService struct {
-	repo repository.BorrowRepository
+	repo           repository.BorrowRepository
+	bookRepo       repository.BookRepository
+	memberRepo     repository.MemberRepository
+	bookSvc        BookService
+	copyRepo       repository.BookCopyRepository
+	renewalRepo    repository.BorrowRenewalRepository
+	reservationSvc ReservationService
+	fineSvc        FineService
+	memberSvc      MemberService
+	policySvc      LoanPolicyService
+	branchSvc      BranchService
+	txManager      postgres.TxManager
+	paging         config.PagingConfig
 }
 
-func NewBorrowService(repo repository.BorrowRepository) BorrowService {
-	return &borrowService{repo: repo}
+func NewBorrowService(
+	repo repository.BorrowRepository,
+	bookRepo repository.BookRepository,
+	memberRepo repository.MemberRepository,
+	bookSvc BookService,
+	copyRepo repository.BookCopyRepository,
+	renewalRepo repository.BorrowRenewalRepository,
+	reservationSvc ReservationService,
+	fineSvc FineService,
+	memberSvc MemberService,
+	policySvc LoanPolicyService,
+	branchSvc BranchService,
+	txManager postgres.TxManager,
+	cfg *config.Config,
+) BorrowService {
+	return &borrowService{
+		repo:           repo,
+		bookRepo:       bookRepo,
+		memberRepo:     memberRepo,
+		bookSvc:        bookSvc,
+		copyRepo:       copyRepo,
+		renewalRepo:    renewalRepo,
+		reservationSvc: reservationSvc,
+		fineSvc:        fineSvc,
+		memberSvc:      memberSvc,
+		policySvc:      policySvc,
+		branchSvc:      branchSvc,
+		txManager:      txManager,
+		paging:         cfg.Paging,
+	}
 }
 
-func (s *borrowService) ListBorrowLists(ctx context.Context, filters, sorts []string, fields string) ([]model.Borrow, error) {
-	f, err := query.ParseFilters(filters)
+func (s *borrowService) ListBorrowLists(ctx context.Context, filters, sorts []string, fields, status, q, expand string, page query.PageRequest) ([]model.Borrow, query.PageInfo, error) {
+	e, err := query.ParseExpand(expand, BorrowExpansions...)
 	if err != nil {
-		return nil, err
+		return nil, query.PageInfo{}, err
 	}
-	srts, err := query.ParseSorts(sorts)
+	f, err := query.ParseFilters(repository.BorrowSchema, filters)
 	if err != nil {
-		return nil, err
+		return nil, query.PageInfo{}, err
+	}
+	where, err := query.ParseExpr(repository.BorrowSchema, q)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	sf, err := statusFilters(status, time.Now())
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	f = append(f, sf...)
+	srts, err := query.ParseSorts(repository.BorrowSchema, sorts)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	fs, err := query.ParseFields(repository.BorrowSchema, fields)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	if e.Has("book") {
+		fs = query.RequireFields(fs, "book_id")
+	}
+	if e.Has("member") {
+		fs = query.RequireFields(fs, "member_id")
+	}
+	p, err := query.NewPage(page, srts, s.paging.DefaultPageSize, s.paging.MaxPageSize)
+	if err != nil {
+		return nil, query.PageInfo{}, err
 	}
-	fs := query.ParseFields(fields)
 
 	opts := query.QueryOptions{
 		Filters: f,
+		Where:   where,
 		Sorts:   srts,
 		Fields:  fs,
+		Page:    p,
+	}
+	borrows, err := s.repo.GetAllBorrowLists(ctx, opts)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	borrows, info, err := query.Paginate(borrows, opts)
+	if err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	if p.WithTotal {
+		total, err := s.repo.CountBorrows(ctx, opts)
+		if err != nil {
+			return nil, query.PageInfo{}, err
+		}
+		info.Total = &total
 	}
+	if err := s.expandBorrows(ctx, borrows, e); err != nil {
+		return nil, query.PageInfo{}, err
+	}
+	return borrows, info, nil
+}
 
-	return s.repo.GetAllBorrowLists(ctx, opts)
+func (s *borrowService) AggregateBorrows(ctx context.Context, filters []string, status, q, groupBy, agg string) ([]map[string]interface{}, error) {
+	f, err := query.ParseFilters(repository.BorrowSchema, filters)
+	if err != nil {
+		return nil, err
+	}
+	where, err := query.ParseExpr(repository.BorrowSchema, q)
+	if err != nil {
+		return nil, err
+	}
+	sf, err := statusFilters(status, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	f = append(f, sf...)
+	groups, err := query.ParseGroupBy(repository.BorrowSchema, groupBy)
+	if err != nil {
+		return nil, err
+	}
+	aggs, err := query.ParseAggregates(repository.BorrowSchema, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := query.QueryOptions{
+		Filters:    f,
+		Where:      where,
+		GroupBy:    groups,
+		Aggregates: aggs,
+	}
+	return s.repo.AggregateBorrows(ctx, opts)
 }
 
 func (s *borrowService) GetBorrow(ctx context.Context, id int) (*model.Borrow, error) {
 	return s.repo.GetBorrowByID(ctx, id)
 }
 
-func (s *borrowService) CreateBorrow(ctx context.Context, bookID int, userName string, borrowedAt int64) (*model.Borrow, error) {
+func (s *borrowService) ExpandBorrows(ctx context.Context, borrows []model.Borrow, expand string) error {
+	e, err := query.ParseExpand(expand, BorrowExpansions...)
+	if err != nil {
+		return err
+	}
+	return s.expandBorrows(ctx, borrows, e)
+}
+
+// expandBorrows loads each expanded relation of all the borrows with a single
+// query, and the relations of their books through the book service.
+func (s *borrowService) expandBorrows(ctx context.Context, borrows []model.Borrow, e query.Expand) error {
+	if len(borrows) == 0 {
+		return nil
+	}
+
+	if e.Has("book") {
+		ids := make([]int, len(borrows))
+		for i, b := range borrows {
+			ids[i] = b.BookID
+		}
+		books, err := s.bookRepo.GetBooksByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		if err := s.bookSvc.ExpandBooks(ctx, books, e.Sub("book").String()); err != nil {
+			return err
+		}
+		byID := make(map[int]*model.Book, len(books))
+		for i := range books {
+			byID[books[i].ID] = &books[i]
+		}
+		for i := range borrows {
+			borrows[i].Book = byID[borrows[i].BookID]
+		}
+	}
+
+	if e.Has("member") {
+		ids := make([]int, len(borrows))
+		for i, b := range borrows {
+			ids[i] = b.MemberID
+		}
+		members, err := s.memberRepo.GetMembersByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		byID := make(map[int]*model.Member, len(members))
+		for i := range members {
+			byID[members[i].ID] = &members[i]
+		}
+		for i := range borrows {
+			borrows[i].Member = byID[borrows[i].MemberID]
+		}
+	}
+	return nil
+}
+
+// CreateBorrow claims the copy, records the loan and fulfills the reservation
+// of the patron in a single transaction.
+func (s *borrowService) CreateBorrow(ctx context.Context, bookID, copyID, branchID, memberID int, borrowedAt int64) (*model.Borrow, error) {
+	var b *model.Borrow
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) (err error) {
+		b, err = s.createBorrow(ctx, bookID, copyID, branchID, memberID, borrowedAt)
+		return err
+	})
+	return b, err
+}
+
+func (s *borrowService) createBorrow(ctx context.Context, bookID, copyID, branchID, memberID int, borrowedAt int64) (*model.Borrow, error) {
+	if branchID != 0 {
+		if err := s.branchSvc.EnsureBranchExists(ctx, branchID); err != nil {
+			return nil, err
+		}
+	}
+	member, err := s.memberSvc.CheckCanBorrow(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fineSvc.CheckBorrowingAllowed(ctx, memberID); err != nil {
+		return nil, err
+	}
+	book, err := s.resolveBook(ctx, bookID, copyID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.policySvc.CheckLoanLimits(ctx, member, book.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	if bookID != 0 {
+		// Holds that were not picked up in time free their copies first
+		if err := s.reservationSvc.ExpireReservations(ctx, bookID); err != nil {
+			return nil, err
+		}
+	}
+
+	held, err := s.reservationSvc.FindHeldReservation(ctx, bookID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if held != nil && copyID != 0 && copyID != *held.CopyID {
+		// The patron picked another copy, leave the held one in place
+		held = nil
+	}
+	if held != nil && branchID != 0 {
+		heldCopy, err := s.copyRepo.GetCopyByID(ctx, *held.CopyID)
+		if err != nil {
+			return nil, err
+		}
+		if heldCopy != nil && heldCopy.BranchID != branchID {
+			// The copy waits at another branch, lend one from this branch instead
+			held = nil
+		}
+	}
+
+	var c *model.BookCopy
+	if held != nil {
+		c, err = s.copyRepo.ClaimCopy(ctx, *held.CopyID, model.CopyStatusOnHold)
+		if err == nil && c == nil {
+			err = ErrCopyNotAvailable
+		}
+	} else {
+		c, err = s.claimCopy(ctx, bookID, copyID, branchID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	newBorrow := model.Borrow{
-		BookID:     bookID,
-		UserName:   userName,
+		BookID:     c.BookID,
+		CopyID:     c.ID,
+		BranchID:   c.BranchID,
+		MemberID:   memberID,
 		BorrowedAt: borrowedAt,
+		DueAt:      dueAt(borrowedAt, policy),
 	}
 	id, err := s.repo.CreateBorrow(ctx, newBorrow)
 	if err != nil {
 		return nil, err
 	}
 	newBorrow.ID = id
+
+	if held != nil {
+		err = s.reservationSvc.FulfillReservation(ctx, held.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &newBorrow, nil
 }
 
-func (s *borrowService) UpdateBorrow(ctx context.Context, id int, bookID int, userName string, borrowedAt int64) (*model.Borrow, error) {
+// resolveBook returns the book of a new loan, given directly or through the requested copy.
+func (s *borrowService) resolveBook(ctx context.Context, bookID, copyID int) (*model.Book, error) {
+	if bookID == 0 && copyID != 0 {
+		c, err := s.copyRepo.GetCopyByID(ctx, copyID)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			return nil, ErrCopyNotFound
+		}
+		bookID = c.BookID
+	}
+	book, err := s.bookRepo.GetBookByID(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil {
+		return nil, ErrBookNotFound
+	}
+	return book, nil
+}
+
+// claimCopy reserves a copy of the book for a new loan. A specific copy is
+// claimed when copyID is set, otherwise the first available copy of the book.
+// Only copies at the given branch are lent unless branchID is 0.
+func (s *borrowService) claimCopy(ctx context.Context, bookID, copyID, branchID int) (*model.BookCopy, error) {
+	if copyID == 0 {
+		c, err := s.copyRepo.ClaimAvailableCopy(ctx, bookID, branchID)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			return nil, ErrNoCopyAvailable
+		}
+		return c, nil
+	}
+
+	existing, err := s.copyRepo.GetCopyByID(ctx, copyID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrCopyNotFound
+	}
+	if bookID != 0 && existing.BookID != bookID {
+		return nil, ErrCopyBookMismatch
+	}
+	if branchID != 0 && existing.BranchID != branchID {
+		return nil, ErrCopyBranchMismatch
+	}
+	c, err := s.copyRepo.ClaimCopy(ctx, copyID, model.CopyStatusAvailable)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrCopyNotAvailable
+	}
+	return c, nil
+}
+
+func (s *borrowService) UpdateBorrow(ctx context.Context, id, bookID, memberID int, borrowedAt int64) (*model.Borrow, error) {
 	b, err := s.GetBorrow(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 	if b == nil {
-		return nil, fmt.Errorf("borrow not found")
+		return nil, ErrBorrowNotFound
+	}
+	if bookID != b.BookID {
+		return nil, ErrBorrowBookChanged
+	}
+	if memberID != b.MemberID {
+		m, err := s.memberSvc.GetMember(ctx, memberID)
+		if err != nil {
+			return nil, err
+		}
+		if m == nil {
+			return nil, ErrMemberNotFound
+		}
 	}
 
+	old := *b
 	b.BookID = bookID
-	b.UserName = userName
-	b.BorrowedAt = borrowedAt
+	b.MemberID = memberID
+	if borrowedAt != b.BorrowedAt {
+		if b.RenewalCount == 0 {
+			// The loan period of the policy runs from the corrected start
+			policy, err := s.loanPolicy(ctx, b)
+			if err != nil {
+				return nil, err
+			}
+			b.DueAt = dueAt(borrowedAt, policy)
+		} else {
+			// Renewals extended the due date from when they were made, keep them
+			b.DueAt += borrowedAt - b.BorrowedAt
+		}
+		b.BorrowedAt = borrowedAt
+	}
 
-	err = s.repo.UpdateBorrow(ctx, *b)
+	err = s.repo.UpdateBorrow(ctx, old, *b)
 	if err != nil {
 		return nil, err
 	}
@@ -90,5 +450,267 @@ func (s *borrowService) DeleteBorrow(ctx context.Context, id int) error {
 	if b == nil {
 		return fmt.Errorf("borrow not found with id %d", id)
 	}
-	return s.repo.DeleteBorrow(ctx, id)
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.repo.DeleteBorrow(ctx, id)
+		if err != nil {
+			return err
+		}
+		if b.ReturnedAt == nil {
+			return s.reservationSvc.ReleaseCopy(ctx, b.BookID, b.CopyID)
+		}
+		return nil
+	})
+}
+
+// ReturnBorrow closes the loan, moves and releases the copy and assesses the
+// overdue fine in a single transaction.
+func (s *borrowService) ReturnBorrow(ctx context.Context, id, branchID int) (*model.Borrow, error) {
+	var b *model.Borrow
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) (err error) {
+		b, err = s.returnBorrow(ctx, id, branchID)
+		return err
+	})
+	return b, err
+}
+
+func (s *borrowService) returnBorrow(ctx context.Context, id, branchID int) (*model.Borrow, error) {
+	b, err := s.GetBorrow(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBorrowNotFound
+	}
+	if b.ReturnedAt != nil {
+		return nil, ErrBorrowAlreadyReturned
+	}
+	if branchID == 0 {
+		branchID = b.BranchID
+	} else if err := s.branchSvc.EnsureBranchExists(ctx, branchID); err != nil {
+		return nil, err
+	}
+
+	returnedAt := time.Now().Unix()
+	err = s.repo.ReturnBorrow(ctx, id, returnedAt, branchID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNoRowsUpdated) {
+			// Another request returned the borrow in the meantime
+			return nil, ErrBorrowAlreadyReturned
+		}
+		return nil, err
+	}
+	b.ReturnedAt = &returnedAt
+	b.ReturnBranchID = &branchID
+
+	if branchID != b.BranchID {
+		// The copy stays where it was returned until a transfer moves it back
+		err = s.copyRepo.MoveCopy(ctx, b.CopyID, branchID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The copy goes to the next patron waiting for the book, if any
+	err = s.reservationSvc.ReleaseCopy(ctx, b.BookID, b.CopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.fineSvc.AssessOverdueFine(ctx, *b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// RenewBorrow extends the due date of an open borrow by a loan period.
+// Every attempt is recorded, refused ones together with the reason.
+func (s *borrowService) RenewBorrow(ctx context.Context, id int) (*model.Borrow, error) {
+	b, err := s.GetBorrow(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBorrowNotFound
+	}
+
+	now := time.Now()
+	renewal := model.BorrowRenewal{
+		BorrowID:      b.ID,
+		AttemptedAt:   now.Unix(),
+		PreviousDueAt: b.DueAt,
+	}
+
+	policy, err := s.loanPolicy(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	refusal, err := s.checkRenewal(ctx, b, policy)
+	if err != nil {
+		return nil, err
+	}
+	if refusal != nil {
+		return nil, s.refuseRenewal(ctx, renewal, refusal)
+	}
+
+	// Overdue loans are extended from today rather than from their past due date
+	start := b.DueAt
+	if start < now.Unix() {
+		start = now.Unix()
+	}
+	newDueAt := dueAt(start, policy)
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.repo.RenewBorrow(ctx, b.ID, policy.MaxRenewals, newDueAt)
+		if err != nil {
+			return err
+		}
+		renewal.Succeeded = true
+		renewal.NewDueAt = &newDueAt
+		_, err = s.renewalRepo.CreateRenewal(ctx, renewal)
+		return err
+	})
+	if errors.Is(err, repository.ErrNoRowsUpdated) {
+		// Another request returned or renewed the borrow since it was checked
+		current, getErr := s.GetBorrow(ctx, id)
+		if getErr != nil {
+			return nil, getErr
+		}
+		if current == nil {
+			return nil, ErrBorrowNotFound
+		}
+		refusal, getErr = s.checkRenewal(ctx, current, policy)
+		if getErr != nil {
+			return nil, getErr
+		}
+		if refusal != nil {
+			renewal.Succeeded = false
+			renewal.NewDueAt = nil
+			return nil, s.refuseRenewal(ctx, renewal, refusal)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.DueAt = newDueAt
+	b.RenewalCount++
+	return b, nil
+}
+
+// refuseRenewal records a refused renewal attempt with its reason, outside of
+// any transaction so that it is kept although the renewal fails, and returns
+// the refusal.
+func (s *borrowService) refuseRenewal(ctx context.Context, renewal model.BorrowRenewal, refusal error) error {
+	renewal.Reason = refusal.Error()
+	if _, err := s.renewalRepo.CreateRenewal(ctx, renewal); err != nil {
+		return err
+	}
+	return refusal
+}
+
+// checkRenewal returns the reason a borrow cannot be renewed, or nil when it can.
+func (s *borrowService) checkRenewal(ctx context.Context, b *model.Borrow, policy *model.LoanPolicy) (refusal error, err error) {
+	if b.ReturnedAt != nil {
+		return ErrBorrowAlreadyReturned, nil
+	}
+	if b.RenewalCount >= policy.MaxRenewals {
+		return &PolicyViolationError{
+			Rule:           RuleMaxRenewals,
+			PolicyID:       policy.ID,
+			MemberCategory: policy.MemberCategory,
+			BookCategory:   policy.BookCategory,
+			Limit:          policy.MaxRenewals,
+			Current:        b.RenewalCount,
+		}, nil
+	}
+	reserved, err := s.reservationSvc.HasOtherReservations(ctx, b.BookID, b.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return ErrBookReserved, nil
+	}
+	return nil, nil
+}
+
+func (s *borrowService) ListRenewals(ctx context.Context, id int) ([]model.BorrowRenewal, error) {
+	b, err := s.GetBorrow(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBorrowNotFound
+	}
+	return s.renewalRepo.GetRenewalsByBorrowID(ctx, id)
+}
+
+func (s *borrowService) ListMemberBorrows(ctx context.Context, memberID int, loans string, page, pageSize int) ([]model.BorrowHistoryEntry, int, error) {
+	if loans != "" && loans != model.BorrowHistoryCurrent && loans != model.BorrowHistoryPast {
+		return nil, 0, fmt.Errorf("%w: %s", ErrInvalidBorrowStatus, loans)
+	}
+	m, err := s.memberSvc.GetMember(ctx, memberID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if m == nil {
+		return nil, 0, ErrMemberNotFound
+	}
+
+	total, err := s.repo.CountBorrowHistory(ctx, memberID, loans)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries, err := s.repo.GetBorrowHistory(ctx, memberID, loans, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// loanPolicy returns the policy an existing borrow falls under.
+func (s *borrowService) loanPolicy(ctx context.Context, b *model.Borrow) (*model.LoanPolicy, error) {
+	m, err := s.memberSvc.GetMember(ctx, b.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, ErrMemberNotFound
+	}
+	book, err := s.bookRepo.GetBookByID(ctx, b.BookID)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil {
+		return nil, ErrBookNotFound
+	}
+	return s.policySvc.ResolvePolicy(ctx, m.Category, book.Category)
+}
+
+// dueAt computes the due date of a loan under the policy starting at borrowedAt.
+func dueAt(borrowedAt int64, policy *model.LoanPolicy) int64 {
+	return time.Unix(borrowedAt, 0).Add(policy.LoanPeriod()).Unix()
+}
+
+// statusFilters translates a borrow status into query filters. An empty status matches every borrow.
+func statusFilters(status string, now time.Time) ([]query.Filter, error) {
+	switch status {
+	case "":
+		return nil, nil
+	case model.BorrowStatusOpen:
+		return []query.Filter{
+			{Field: "returned_at", Operator: "isnull"},
+			{Field: "due_at", Operator: "gte", Value: now.Unix()},
+		}, nil
+	case model.BorrowStatusOverdue:
+		return []query.Filter{
+			{Field: "returned_at", Operator: "isnull"},
+			{Field: "due_at", Operator: "lt", Value: now.Unix()},
+		}, nil
+	case model.BorrowStatusReturned:
+		return []query.Filter{
+			{Field: "returned_at", Operator: "notnull"},
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBorrowStatus, status)
+	}
 }
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"borrow_book/internal/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+// BranchService defines the interface for branch-related operations
+type BranchService interface {
+	ListBranches(ctx context.Context, filters, sorts []string, fields string) ([]model.Branch, error)
+	GetBranch(ctx context.Context, id int) (*model.Branch, error)
+	CreateBranch(ctx context.Context, b model.Branch) (*model.Branch, error)
+	UpdateBranch(ctx context.Context, b model.Branch) (*model.Branch, error)
+	DeleteBranch(ctx context.Context, id int) error
+	// EnsureBranchExists returns ErrBranchNotFound unless the branch exists.
+	EnsureBranchExists(ctx context.Context, id int) error
+}
+
+// branchService is the concrete implementation of BranchService
+type branchService struct {
+	repo repository.BranchRepository
+}
+
+// NewBranchService creates a new instance of BranchService
+func NewBranchService(repo repository.BranchRepository) BranchService {
+	return &branchService{repo: repo}
+}
+
+func (s *branchService) ListBranches(ctx context.Context, filters, sorts []string, fields string) ([]model.Branch, error) {
+	f, err := query.ParseFilters(repository.BranchSchema, filters)
+	if err != nil {
+		return nil, err
+	}
+	srts, err := query.ParseSorts(repository.BranchSchema, sorts)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := query.ParseFields(repository.BranchSchema, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := query.QueryOptions{
+		Filters: f,
+		Sorts:   srts,
+		Fields:  fs,
+	}
+	return s.repo.GetAllBranches(ctx, opts)
+}
+
+func (s *branchService) GetBranch(ctx context.Context, id int) (*model.Branch, error) {
+	return s.repo.GetBranchByID(ctx, id)
+}
+
+func (s *branchService) CreateBranch(ctx context.Context, b model.Branch) (*model.Branch, error) {
+	b.Code = strings.ToUpper(strings.TrimSpace(b.Code))
+	if err := s.validate(ctx, b); err != nil {
+		return nil, err
+	}
+	id, err := s.repo.CreateBranch(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	b.ID = id
+	return &b, nil
+}
+
+func (s *branchService) UpdateBranch(ctx context.Context, b model.Branch) (*model.Branch, error) {
+	if err := s.EnsureBranchExists(ctx, b.ID); err != nil {
+		return nil, err
+	}
+	b.Code = strings.ToUpper(strings.TrimSpace(b.Code))
+	if err := s.validate(ctx, b); err != nil {
+		return nil, err
+	}
+	err := s.repo.UpdateBranch(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (s *branchService) DeleteBranch(ctx context.Context, id int) error {
+	if err := s.EnsureBranchExists(ctx, id); err != nil {
+		return err
+	}
+	err := s.repo.DeleteBranch(ctx, id)
+	if errors.Is(err, repository.ErrReferenced) {
+		return ErrBranchInUse
+	}
+	return err
+}
+
+func (s *branchService) EnsureBranchExists(ctx context.Context, id int) error {
+	b, err := s.repo.GetBranchByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return ErrBranchNotFound
+	}
+	return nil
+}
+
+// validate checks that no other branch uses the same code.
+func (s *branchService) validate(ctx context.Context, b model.Branch) error {
+	existing, err := s.repo.GetBranchByCode(ctx, b.Code)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != b.ID {
+		return ErrDuplicateBranchCode
+	}
+	return nil
+}
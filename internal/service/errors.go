@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrBookNotFound          = errors.New("book not found")
+	ErrBorrowNotFound        = errors.New("borrow not found")
+	ErrBorrowAlreadyReturned = errors.New("borrow already returned")
+	ErrBorrowBookChanged     = errors.New("book of a borrow cannot be changed")
+	ErrInvalidBorrowStatus   = errors.New("invalid borrow status")
+	ErrRenewalLimitReached   = errors.New("renewal limit reached")
+	ErrBookReserved          = errors.New("book is reserved by another patron")
+
+	ErrInvalidISBN   = errors.New("invalid isbn")
+	ErrDuplicateISBN = errors.New("isbn already used by another book")
+
+	ErrAuthorNotFound         = errors.New("author not found")
+	ErrInvalidContributorRole = errors.New("invalid contributor role")
+	ErrDuplicateContributor   = errors.New("author is listed twice in the same role")
+
+	ErrPublisherNotFound  = errors.New("publisher not found")
+	ErrDuplicatePublisher = errors.New("publisher already exists")
+	ErrPublisherHasBooks  = errors.New("publisher still has books")
+	ErrInvalidBookFormat  = errors.New("invalid book format")
+	ErrInvalidLanguage    = errors.New("invalid language code")
+
+	ErrCoverNotFound        = errors.New("book has no cover")
+	ErrCoverTooLarge        = errors.New("cover image is too large")
+	ErrUnsupportedCoverType = errors.New("cover must be a jpeg or png image")
+	ErrInvalidCoverImage    = errors.New("invalid cover image")
+	ErrInvalidCoverSize     = errors.New("invalid cover size")
+
+	ErrEmptySearchQuery  = errors.New("search query must not be empty")
+	ErrInvalidSearchType = errors.New("invalid search type")
+
+	ErrCopyNotFound      = errors.New("copy not found")
+	ErrCopyBookMismatch  = errors.New("copy does not belong to the book")
+	ErrCopyOnLoan        = errors.New("copy is on loan")
+	ErrCopyOnHold        = errors.New("copy is held for a reservation")
+	ErrCopyHasBorrows    = errors.New("copy has borrow or transfer history")
+	ErrCopyNotAvailable  = errors.New("copy is not available")
+	ErrDuplicateBarcode  = errors.New("barcode already in use")
+	ErrInvalidCopyStatus = errors.New("invalid copy status")
+	ErrNoCopyAvailable   = errors.New("no copy of the book is available")
+	ErrCopyInTransit     = errors.New("copy is in transit")
+
+	ErrMemberNotFound      = errors.New("member not found")
+	ErrMemberSuspended     = errors.New("member is suspended")
+	ErrMembershipExpired   = errors.New("membership has expired")
+	ErrMemberHasHistory    = errors.New("member has borrow or fine history")
+	ErrDuplicateCardNumber = errors.New("card number already in use")
+	ErrInvalidMemberStatus = errors.New("invalid member status")
+
+	ErrReservationNotFound  = errors.New("reservation not found")
+	ErrReservationNotActive = errors.New("reservation is no longer active")
+	ErrDuplicateReservation = errors.New("patron already has an active reservation on the book")
+	ErrBookAvailable        = errors.New("book has available copies")
+
+	ErrFinesOverThreshold   = errors.New("outstanding fines exceed the borrowing threshold")
+	ErrAmountExceedsBalance = errors.New("amount exceeds the outstanding balance")
+	ErrInvalidAmount        = errors.New("amount must be positive")
+)
+
+var (
+	ErrGenreNotFound       = errors.New("genre not found")
+	ErrParentGenreNotFound = errors.New("parent genre not found")
+	ErrGenreCycle          = errors.New("genre cannot be moved under itself or one of its descendants")
+	ErrGenreHasChildren    = errors.New("genre still has sub-genres")
+	ErrDuplicateGenre      = errors.New("genre already exists under this parent")
+	ErrTagNotFound         = errors.New("tag not found")
+	ErrDuplicateTag        = errors.New("tag already exists")
+	ErrInvalidTag          = errors.New("tag name must not be empty")
+)
+
+var (
+	ErrBranchNotFound      = errors.New("branch not found")
+	ErrDuplicateBranchCode = errors.New("branch code already in use")
+	ErrBranchInUse         = errors.New("branch still has copies, loans or transfers")
+	ErrCopyBranchMismatch  = errors.New("copy is not at the branch")
+
+	ErrTransferNotFound    = errors.New("transfer not found")
+	ErrTransferExists      = errors.New("copy already has an open transfer")
+	ErrTransferSameBranch  = errors.New("copy is already at the destination branch")
+	ErrInvalidTransferStep = errors.New("transfer is not in a status allowing this step")
+)
+
+var (
+	ErrLoanPolicyNotFound  = errors.New("loan policy not found")
+	ErrDuplicateLoanPolicy = errors.New("loan policy already exists for these categories")
+	ErrInvalidLoanPolicy   = errors.New("invalid loan policy")
+	ErrLoanLimitReached    = errors.New("loan limit reached")
+)
+
+// Loan policy rules a PolicyViolationError can report.
+const (
+	RuleMaxLoans    = "max_loans"
+	RuleMaxRenewals = "max_renewals"
+)
+
+// PolicyViolationError reports the loan policy rule that refused a borrow or renewal.
+// It matches ErrLoanLimitReached or ErrRenewalLimitReached with errors.Is.
+type PolicyViolationError struct {
+	Rule           string
+	PolicyID       int // 0 for the library defaults
+	MemberCategory string
+	BookCategory   string
+	Limit          int
+	Current        int
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("%s: %d of %d", e.Unwrap(), e.Current, e.Limit)
+}
+
+func (e *PolicyViolationError) Unwrap() error {
+	if e.Rule == RuleMaxRenewals {
+		return ErrRenewalLimitReached
+	}
+	return ErrLoanLimitReached
+}
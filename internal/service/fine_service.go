@@ -0,0 +1,184 @@
+package service
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/postgres"
+	"borrow_book/internal/infra/database/query"
+	"borrow_book/internal/repository"
+	"context"
+	"fmt"
+	"time"
+)
+
+// FineService defines the interface for fines ledger operations
+type FineService interface {
+	ListEntries(ctx context.Context, filters, sorts []string, fields string) ([]model.FineEntry, error)
+	GetBalance(ctx context.Context, memberID int) (*model.FineBalance, error)
+	ListOutstandingBalances(ctx context.Context) ([]model.FineBalance, error)
+	RecordPayment(ctx context.Context, memberID int, amount int64, reason string) (*model.FineEntry, error)
+	WaiveFine(ctx context.Context, memberID int, amount int64, reason string, borrowID *int) (*model.FineEntry, error)
+	// AssessOverdueFine records the fine of a returned borrow. It returns nil when the borrow was returned on time.
+	AssessOverdueFine(ctx context.Context, b model.Borrow) (*model.FineEntry, error)
+	// CheckBorrowingAllowed fails when the patron owes more than the configured threshold.
+	CheckBorrowingAllowed(ctx context.Context, memberID int) error
+}
+
+// fineService is the concrete implementation of FineService
+type fineService struct {
+	repo           repository.FineRepository
+	memberRepo     repository.MemberRepository
+	txManager      postgres.TxManager
+	dailyRate      int64
+	maxAmount      int64
+	blockThreshold int64
+}
+
+// NewFineService creates a new instance of FineService
+func NewFineService(
+	repo repository.FineRepository,
+	memberRepo repository.MemberRepository,
+	txManager postgres.TxManager,
+	cfg *config.Config,
+) FineService {
+	return &fineService{
+		repo:           repo,
+		memberRepo:     memberRepo,
+		txManager:      txManager,
+		dailyRate:      cfg.Library.FineDailyRate,
+		maxAmount:      cfg.Library.FineMaxAmount,
+		blockThreshold: cfg.Library.FineBlockThreshold,
+	}
+}
+
+func (s *fineService) ListEntries(ctx context.Context, filters, sorts []string, fields string) ([]model.FineEntry, error) {
+	f, err := query.ParseFilters(repository.FineEntrySchema, filters)
+	if err != nil {
+		return nil, err
+	}
+	srts, err := query.ParseSorts(repository.FineEntrySchema, sorts)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := query.ParseFields(repository.FineEntrySchema, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := query.QueryOptions{
+		Filters: f,
+		Sorts:   srts,
+		Fields:  fs,
+	}
+	return s.repo.GetAllEntries(ctx, opts)
+}
+
+func (s *fineService) GetBalance(ctx context.Context, memberID int) (*model.FineBalance, error) {
+	m, err := s.memberRepo.GetMemberByID(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, ErrMemberNotFound
+	}
+	balance, err := s.repo.GetBalance(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.FineBalance{MemberID: memberID, MemberName: m.Name, Balance: balance}, nil
+}
+
+func (s *fineService) ListOutstandingBalances(ctx context.Context) ([]model.FineBalance, error) {
+	return s.repo.GetOutstandingBalances(ctx)
+}
+
+func (s *fineService) RecordPayment(ctx context.Context, memberID int, amount int64, reason string) (*model.FineEntry, error) {
+	return s.settle(ctx, model.FineEntry{
+		MemberID: memberID,
+		Kind:     model.FineKindPayment,
+		Amount:   amount,
+		Reason:   reason,
+	})
+}
+
+func (s *fineService) WaiveFine(ctx context.Context, memberID int, amount int64, reason string, borrowID *int) (*model.FineEntry, error) {
+	return s.settle(ctx, model.FineEntry{
+		MemberID: memberID,
+		BorrowID: borrowID,
+		Kind:     model.FineKindWaiver,
+		Amount:   amount,
+		Reason:   reason,
+	})
+}
+
+// settle records a payment or waiver, which may not exceed what the patron owes.
+// The member row stays locked from the balance check to the new entry, so that
+// concurrent settlements of a patron are checked one after the other.
+func (s *fineService) settle(ctx context.Context, entry model.FineEntry) (*model.FineEntry, error) {
+	if entry.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	var created *model.FineEntry
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		m, err := s.memberRepo.LockMember(ctx, entry.MemberID)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			return ErrMemberNotFound
+		}
+		balance, err := s.repo.GetBalance(ctx, entry.MemberID)
+		if err != nil {
+			return err
+		}
+		if entry.Amount > balance {
+			return fmt.Errorf("%w: %d > %d", ErrAmountExceedsBalance, entry.Amount, balance)
+		}
+		created, err = s.createEntry(ctx, entry)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *fineService) AssessOverdueFine(ctx context.Context, b model.Borrow) (*model.FineEntry, error) {
+	if b.ReturnedAt == nil {
+		return nil, nil
+	}
+	amount := model.CalculateFine(b.DueAt, *b.ReturnedAt, s.dailyRate, s.maxAmount)
+	if amount == 0 {
+		return nil, nil
+	}
+
+	borrowID := b.ID
+	return s.createEntry(ctx, model.FineEntry{
+		MemberID: b.MemberID,
+		BorrowID: &borrowID,
+		Kind:     model.FineKindFine,
+		Amount:   amount,
+		Reason:   fmt.Sprintf("overdue return of borrow %d", b.ID),
+	})
+}
+
+func (s *fineService) CheckBorrowingAllowed(ctx context.Context, memberID int) error {
+	balance, err := s.repo.GetBalance(ctx, memberID)
+	if err != nil {
+		return err
+	}
+	if balance > s.blockThreshold {
+		return fmt.Errorf("%w: owes %d", ErrFinesOverThreshold, balance)
+	}
+	return nil
+}
+
+func (s *fineService) createEntry(ctx context.Context, entry model.FineEntry) (*model.FineEntry, error) {
+	entry.CreatedAt = time.Now().Unix()
+	id, err := s.repo.CreateEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	entry.ID = id
+	return &entry, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"borrow_book/internal/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+// GenreService defines the interface for genre-related operations
+type GenreService interface {
+	ListGenres(ctx context.Context, filters, sorts []string, fields string) ([]model.Genre, error)
+	GetGenre(ctx context.Context, id int) (*model.Genre, error)
+	CreateGenre(ctx context.Context, g model.Genre) (*model.Genre, error)
+	UpdateGenre(ctx context.Context, g model.Genre) (*model.Genre, error)
+	DeleteGenre(ctx context.Context, id int) error
+	AddBookGenre(ctx context.Context, bookID, genreID int) error
+	RemoveBookGenre(ctx context.Context, bookID, genreID int) error
+}
+
+// genreService is the concrete implementation of GenreService
+type genreService struct {
+	repo     repository.GenreRepository
+	bookRepo repository.BookRepository
+}
+
+// NewGenreService creates a new instance of GenreService
+func NewGenreService(repo repository.GenreRepository, bookRepo repository.BookRepository) GenreService {
+	return &genreService{repo: repo, bookRepo: bookRepo}
+}
+
+func (s *genreService) ListGenres(ctx context.Context, filters, sorts []string, fields string) ([]model.Genre, error) {
+	f, err := query.ParseFilters(repository.GenreSchema, filters)
+	if err != nil {
+		return nil, err
+	}
+	srts, err := query.ParseSorts(repository.GenreSchema, sorts)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := query.ParseFields(repository.GenreSchema, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := query.QueryOptions{
+		Filters: f,
+		Sorts:   srts,
+		Fields:  fs,
+	}
+	return s.repo.GetAllGenres(ctx, opts)
+}
+
+func (s *genreService) GetGenre(ctx context.Context, id int) (*model.Genre, error) {
+	return s.repo.GetGenreByID(ctx, id)
+}
+
+func (s *genreService) CreateGenre(ctx context.Context, g model.Genre) (*model.Genre, error) {
+	g.Name = strings.TrimSpace(g.Name)
+	if err := s.validate(ctx, g); err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.CreateGenre(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+	g.ID = id
+	return &g, nil
+}
+
+func (s *genreService) UpdateGenre(ctx context.Context, g model.Genre) (*model.Genre, error) {
+	existing, err := s.GetGenre(ctx, g.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrGenreNotFound
+	}
+	g.Name = strings.TrimSpace(g.Name)
+	if err := s.validate(ctx, g); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.UpdateGenre(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (s *genreService) DeleteGenre(ctx context.Context, id int) error {
+	g, err := s.GetGenre(ctx, id)
+	if err != nil {
+		return err
+	}
+	if g == nil {
+		return ErrGenreNotFound
+	}
+	err = s.repo.DeleteGenre(ctx, id)
+	if errors.Is(err, repository.ErrReferenced) {
+		return ErrGenreHasChildren
+	}
+	return err
+}
+
+func (s *genreService) AddBookGenre(ctx context.Context, bookID, genreID int) error {
+	if err := s.ensureBookExists(ctx, bookID); err != nil {
+		return err
+	}
+	g, err := s.GetGenre(ctx, genreID)
+	if err != nil {
+		return err
+	}
+	if g == nil {
+		return ErrGenreNotFound
+	}
+	return s.repo.AddBookGenre(ctx, bookID, genreID)
+}
+
+func (s *genreService) RemoveBookGenre(ctx context.Context, bookID, genreID int) error {
+	if err := s.ensureBookExists(ctx, bookID); err != nil {
+		return err
+	}
+	err := s.repo.RemoveBookGenre(ctx, bookID, genreID)
+	if err != nil && err.Error() == "no rows deleted" {
+		return ErrGenreNotFound
+	}
+	return err
+}
+
+// validate checks that the parent of a genre exists and is not the genre or
+// one of its descendants, and that no sibling has the same name.
+func (s *genreService) validate(ctx context.Context, g model.Genre) error {
+	if g.ParentID != nil {
+		parent, err := s.GetGenre(ctx, *g.ParentID)
+		if err != nil {
+			return err
+		}
+		if parent == nil {
+			return ErrParentGenreNotFound
+		}
+		if g.ID != 0 {
+			cycle, err := s.repo.IsDescendant(ctx, *g.ParentID, g.ID)
+			if err != nil {
+				return err
+			}
+			if cycle {
+				return ErrGenreCycle
+			}
+		}
+	}
+
+	existing, err := s.repo.GetGenreByName(ctx, g.ParentID, g.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != g.ID {
+		return ErrDuplicateGenre
+	}
+	return nil
+}
+
+func (s *genreService) ensureBookExists(ctx context.Context, bookID int) error {
+	b, err := s.bookRepo.GetBookByID(ctx, bookID)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return ErrBookNotFound
+	}
+	return nil
+}
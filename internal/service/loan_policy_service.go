@@ -0,0 +1,195 @@
+package service
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"borrow_book/internal/repository"
+	"context"
+	"fmt"
+)
+
+// LoanPolicyService defines the interface for loan policy operations
+type LoanPolicyService interface {
+	ListPolicies(ctx context.Context, filters, sorts []string, fields string) ([]model.LoanPolicy, error)
+	GetPolicy(ctx context.Context, id int) (*model.LoanPolicy, error)
+	CreatePolicy(ctx context.Context, p model.LoanPolicy) (*model.LoanPolicy, error)
+	UpdatePolicy(ctx context.Context, p model.LoanPolicy) (*model.LoanPolicy, error)
+	DeletePolicy(ctx context.Context, id int) error
+	// ResolvePolicy returns the most specific policy for a loan: the one of the
+	// member and book category, else the general one of the member category,
+	// else the library defaults.
+	ResolvePolicy(ctx context.Context, memberCategory, bookCategory string) (*model.LoanPolicy, error)
+	// CheckLoanLimits fails with a PolicyViolationError when the member already
+	// holds as many loans as a policy applying to the book allows. It returns
+	// the policy of the new loan.
+	CheckLoanLimits(ctx context.Context, m *model.Member, bookCategory string) (*model.LoanPolicy, error)
+}
+
+// loanPolicyService is the concrete implementation of LoanPolicyService
+type loanPolicyService struct {
+	repo       repository.LoanPolicyRepository
+	borrowRepo repository.BorrowRepository
+	defaults   model.LoanPolicy
+}
+
+// NewLoanPolicyService creates a new instance of LoanPolicyService
+func NewLoanPolicyService(
+	repo repository.LoanPolicyRepository,
+	borrowRepo repository.BorrowRepository,
+	cfg *config.Config,
+) LoanPolicyService {
+	return &loanPolicyService{
+		repo:       repo,
+		borrowRepo: borrowRepo,
+		defaults: model.LoanPolicy{
+			MaxLoans:       cfg.Library.MaxLoans,
+			LoanPeriodDays: cfg.Library.LoanPeriodDays,
+			MaxRenewals:    cfg.Library.MaxRenewals,
+		},
+	}
+}
+
+func (s *loanPolicyService) ListPolicies(ctx context.Context, filters, sorts []string, fields string) ([]model.LoanPolicy, error) {
+	f, err := query.ParseFilters(repository.LoanPolicySchema, filters)
+	if err != nil {
+		return nil, err
+	}
+	srts, err := query.ParseSorts(repository.LoanPolicySchema, sorts)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := query.ParseFields(repository.LoanPolicySchema, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := query.QueryOptions{
+		Filters: f,
+		Sorts:   srts,
+		Fields:  fs,
+	}
+	return s.repo.GetAllPolicies(ctx, opts)
+}
+
+func (s *loanPolicyService) GetPolicy(ctx context.Context, id int) (*model.LoanPolicy, error) {
+	return s.repo.GetPolicyByID(ctx, id)
+}
+
+func (s *loanPolicyService) CreatePolicy(ctx context.Context, p model.LoanPolicy) (*model.LoanPolicy, error) {
+	if err := s.validate(ctx, p); err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.CreatePolicy(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	p.ID = id
+	return &p, nil
+}
+
+func (s *loanPolicyService) UpdatePolicy(ctx context.Context, p model.LoanPolicy) (*model.LoanPolicy, error) {
+	existing, err := s.GetPolicy(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrLoanPolicyNotFound
+	}
+	if err := s.validate(ctx, p); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.UpdatePolicy(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (s *loanPolicyService) DeletePolicy(ctx context.Context, id int) error {
+	p, err := s.GetPolicy(ctx, id)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return ErrLoanPolicyNotFound
+	}
+	return s.repo.DeletePolicy(ctx, id)
+}
+
+func (s *loanPolicyService) ResolvePolicy(ctx context.Context, memberCategory, bookCategory string) (*model.LoanPolicy, error) {
+	if bookCategory != "" {
+		p, err := s.repo.GetPolicy(ctx, memberCategory, bookCategory)
+		if err != nil || p != nil {
+			return p, err
+		}
+	}
+	p, err := s.repo.GetPolicy(ctx, memberCategory, "")
+	if err != nil || p != nil {
+		return p, err
+	}
+	defaults := s.defaults
+	defaults.MemberCategory = memberCategory
+	return &defaults, nil
+}
+
+func (s *loanPolicyService) CheckLoanLimits(ctx context.Context, m *model.Member, bookCategory string) (*model.LoanPolicy, error) {
+	policy, err := s.ResolvePolicy(ctx, m.Category, bookCategory)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMaxLoans(ctx, m, policy); err != nil {
+		return nil, err
+	}
+
+	// A book category policy does not lift the overall limit of the member
+	if policy.BookCategory != "" {
+		general, err := s.ResolvePolicy(ctx, m.Category, "")
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkMaxLoans(ctx, m, general); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
+// checkMaxLoans compares the open loans of the member covered by the policy with its limit.
+func (s *loanPolicyService) checkMaxLoans(ctx context.Context, m *model.Member, p *model.LoanPolicy) error {
+	if p.MaxLoans == 0 {
+		return nil
+	}
+	count, err := s.borrowRepo.CountOpenBorrows(ctx, m.ID, p.BookCategory)
+	if err != nil {
+		return err
+	}
+	if count >= p.MaxLoans {
+		return &PolicyViolationError{
+			Rule:           RuleMaxLoans,
+			PolicyID:       p.ID,
+			MemberCategory: p.MemberCategory,
+			BookCategory:   p.BookCategory,
+			Limit:          p.MaxLoans,
+			Current:        count,
+		}
+	}
+	return nil
+}
+
+// validate checks the limits of a policy and that no other policy covers the same categories.
+func (s *loanPolicyService) validate(ctx context.Context, p model.LoanPolicy) error {
+	if p.MaxLoans < 0 || p.LoanPeriodDays <= 0 || p.MaxRenewals < 0 {
+		return fmt.Errorf("%w: limits must not be negative and the loan period must be positive", ErrInvalidLoanPolicy)
+	}
+	existing, err := s.repo.GetPolicy(ctx, p.MemberCategory, p.BookCategory)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != p.ID {
+		return ErrDuplicateLoanPolicy
+	}
+	return nil
+}
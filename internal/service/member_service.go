@@ -0,0 +1,146 @@
+package service
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"borrow_book/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MemberService defines the interface for member-related operations
+type MemberService interface {
+	ListMembers(ctx context.Context, filters, sorts []string, fields string) ([]model.Member, error)
+	GetMember(ctx context.Context, id int) (*model.Member, error)
+	CreateMember(ctx context.Context, m model.Member) (*model.Member, error)
+	UpdateMember(ctx context.Context, m model.Member) (*model.Member, error)
+	DeleteMember(ctx context.Context, id int) error
+	// CheckCanBorrow fails unless the member exists, is active and has a valid membership.
+	CheckCanBorrow(ctx context.Context, id int) (*model.Member, error)
+}
+
+// memberService is the concrete implementation of MemberService
+type memberService struct {
+	repo repository.MemberRepository
+}
+
+// NewMemberService creates a new instance of MemberService
+func NewMemberService(repo repository.MemberRepository) MemberService {
+	return &memberService{repo: repo}
+}
+
+func (s *memberService) ListMembers(ctx context.Context, filters, sorts []string, fields string) ([]model.Member, error) {
+	f, err := query.ParseFilters(repository.MemberSchema, filters)
+	if err != nil {
+		return nil, err
+	}
+	srts, err := query.ParseSorts(repository.MemberSchema, sorts)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := query.ParseFields(repository.MemberSchema, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := query.QueryOptions{
+		Filters: f,
+		Sorts:   srts,
+		Fields:  fs,
+	}
+	return s.repo.GetAllMembers(ctx, opts)
+}
+
+func (s *memberService) GetMember(ctx context.Context, id int) (*model.Member, error) {
+	return s.repo.GetMemberByID(ctx, id)
+}
+
+func (s *memberService) CreateMember(ctx context.Context, m model.Member) (*model.Member, error) {
+	if m.Status == "" {
+		m.Status = model.MemberStatusActive
+	}
+	if m.Category == "" {
+		m.Category = model.DefaultMemberCategory
+	}
+	if err := s.validate(ctx, m); err != nil {
+		return nil, err
+	}
+
+	id, err := s.repo.CreateMember(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	m.ID = id
+	return &m, nil
+}
+
+func (s *memberService) UpdateMember(ctx context.Context, m model.Member) (*model.Member, error) {
+	existing, err := s.GetMember(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrMemberNotFound
+	}
+	if m.Category == "" {
+		m.Category = model.DefaultMemberCategory
+	}
+	if err := s.validate(ctx, m); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.UpdateMember(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (s *memberService) DeleteMember(ctx context.Context, id int) error {
+	m, err := s.GetMember(ctx, id)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return ErrMemberNotFound
+	}
+	err = s.repo.DeleteMember(ctx, id)
+	if errors.Is(err, repository.ErrReferenced) {
+		return ErrMemberHasHistory
+	}
+	return err
+}
+
+func (s *memberService) CheckCanBorrow(ctx context.Context, id int) (*model.Member, error) {
+	m, err := s.GetMember(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, ErrMemberNotFound
+	}
+	if m.Status != model.MemberStatusActive {
+		return nil, ErrMemberSuspended
+	}
+	if m.IsExpired(time.Now()) {
+		return nil, ErrMembershipExpired
+	}
+	return m, nil
+}
+
+// validate checks the status of a member and that its card number is not used by another member.
+func (s *memberService) validate(ctx context.Context, m model.Member) error {
+	if m.Status != model.MemberStatusActive && m.Status != model.MemberStatusSuspended {
+		return fmt.Errorf("%w: %s", ErrInvalidMemberStatus, m.Status)
+	}
+	existing, err := s.repo.GetMemberByCardNumber(ctx, m.CardNumber)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != m.ID {
+		return ErrDuplicateCardNumber
+	}
+	return nil
+}
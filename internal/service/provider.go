@@ -5,5 +5,17 @@ import "github.com/google/wire"
 var ProviderSetService = wire.NewSet(
 	NewBookService,
 	NewAuthorService,
+	NewPublisherService,
 	NewBorrowService,
+	NewBookCopyService,
+	NewReservationService,
+	NewFineService,
+	NewMemberService,
+	NewLoanPolicyService,
+	NewGenreService,
+	NewTagService,
+	NewBranchService,
+	NewTransferService,
+	NewBookCoverService,
+	NewSearchService,
 )
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"borrow_book/internal/repository"
+	"context"
+	"errors"
+)
+
+// PublisherService defines the interface for publisher-related operations
+type PublisherService interface {
+	ListPublishers(ctx context.Context, filters, sorts []string, fields string) ([]model.Publisher, error)
+	GetPublisher(ctx context.Context, id int) (*model.Publisher, error)
+	CreatePublisher(ctx context.Context, name string) (*model.Publisher, error)
+	UpdatePublisher(ctx context.Context, id int, name string) (*model.Publisher, error)
+	DeletePublisher(ctx context.Context, id int) error
+}
+
+// publisherService is the concrete implementation of PublisherService
+type publisherService struct {
+	repo repository.PublisherRepository
+}
+
+// NewPublisherService creates a new instance of PublisherService
+func NewPublisherService(repo repository.PublisherRepository) PublisherService {
+	return &publisherService{repo: repo}
+}
+
+func (s *publisherService) ListPublishers(ctx context.Context, filters, sorts []string, fields string) ([]model.Publisher, error) {
+	f, err := query.ParseFilters(repository.PublisherSchema, filters)
+	if err != nil {
+		return nil, err
+	}
+	srts, err := query.ParseSorts(repository.PublisherSchema, sorts)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := query.ParseFields(repository.PublisherSchema, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := query.QueryOptions{
+		Filters: f,
+		Sorts:   srts,
+		Fields:  fs,
+	}
+	return s.repo.GetAllPublishers(ctx, opts)
+}
+
+func (s *publisherService) GetPublisher(ctx context.Context, id int) (*model.Publisher, error) {
+	return s.repo.GetPublisherByID(ctx, id)
+}
+
+func (s *publisherService) CreatePublisher(ctx context.Context, name string) (*model.Publisher, error) {
+	newPublisher := model.Publisher{
+		Name: name,
+	}
+	if err := s.validate(ctx, newPublisher); err != nil {
+		return nil, err
+	}
+	id, err := s.repo.CreatePublisher(ctx, newPublisher)
+	if err != nil {
+		return nil, err
+	}
+	newPublisher.ID = id
+	return &newPublisher, nil
+}
+
+func (s *publisherService) UpdatePublisher(ctx context.Context, id int, name string) (*model.Publisher, error) {
+	p, err := s.GetPublisher(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, ErrPublisherNotFound
+	}
+
+	p.Name = name
+	if err := s.validate(ctx, *p); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.UpdatePublisher(ctx, *p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *publisherService) DeletePublisher(ctx context.Context, id int) error {
+	p, err := s.GetPublisher(ctx, id)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		return ErrPublisherNotFound
+	}
+	err = s.repo.DeletePublisher(ctx, id)
+	if errors.Is(err, repository.ErrReferenced) {
+		return ErrPublisherHasBooks
+	}
+	return err
+}
+
+// validate checks that no other publisher has the same name, ignoring case.
+func (s *publisherService) validate(ctx context.Context, p model.Publisher) error {
+	existing, err := s.repo.GetPublisherByName(ctx, p.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != p.ID {
+		return ErrDuplicatePublisher
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/postgres"
+	"borrow_book/internal/repository"
+	"context"
+	"errors"
+	"time"
+)
+
+// ReservationService defines the interface for reservation operations
+type ReservationService interface {
+	ListBookReservations(ctx context.Context, bookID int) ([]model.Reservation, error)
+	GetReservation(ctx context.Context, id int) (*model.Reservation, error)
+	CreateReservation(ctx context.Context, bookID, memberID int) (*model.Reservation, error)
+	CancelReservation(ctx context.Context, id int) error
+	// ReleaseCopy hands a copy that came back on the shelf to the next patron
+	// in the queue of its book, or makes it available when nobody is waiting.
+	ReleaseCopy(ctx context.Context, bookID, copyID int) error
+	// FindHeldReservation returns the ready reservation of a patron on a book, if any.
+	FindHeldReservation(ctx context.Context, bookID, memberID int) (*model.Reservation, error)
+	FulfillReservation(ctx context.Context, id int) error
+	// HasOtherReservations reports whether patrons other than memberID wait for the book.
+	HasOtherReservations(ctx context.Context, bookID, memberID int) (bool, error)
+	// ExpireReservations ends the ready reservations of a book that were not picked up in time.
+	ExpireReservations(ctx context.Context, bookID int) error
+}
+
+// reservationService is the concrete implementation of ReservationService
+type reservationService struct {
+	repo         repository.ReservationRepository
+	bookRepo     repository.BookRepository
+	copyRepo     repository.BookCopyRepository
+	memberSvc    MemberService
+	txManager    postgres.TxManager
+	pickupPeriod time.Duration
+}
+
+// NewReservationService creates a new instance of ReservationService
+func NewReservationService(
+	repo repository.ReservationRepository,
+	bookRepo repository.BookRepository,
+	copyRepo repository.BookCopyRepository,
+	memberSvc MemberService,
+	txManager postgres.TxManager,
+	cfg *config.Config,
+) ReservationService {
+	return &reservationService{
+		repo:         repo,
+		bookRepo:     bookRepo,
+		copyRepo:     copyRepo,
+		memberSvc:    memberSvc,
+		txManager:    txManager,
+		pickupPeriod: time.Duration(cfg.Library.HoldPickupDays) * 24 * time.Hour,
+	}
+}
+
+func (s *reservationService) ListBookReservations(ctx context.Context, bookID int) ([]model.Reservation, error) {
+	if err := s.ensureBookExists(ctx, bookID); err != nil {
+		return nil, err
+	}
+	if err := s.ExpireReservations(ctx, bookID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetActiveReservationsByBookID(ctx, bookID)
+}
+
+func (s *reservationService) GetReservation(ctx context.Context, id int) (*model.Reservation, error) {
+	return s.repo.GetReservationByID(ctx, id)
+}
+
+func (s *reservationService) CreateReservation(ctx context.Context, bookID, memberID int) (*model.Reservation, error) {
+	if err := s.ensureBookExists(ctx, bookID); err != nil {
+		return nil, err
+	}
+	if _, err := s.memberSvc.CheckCanBorrow(ctx, memberID); err != nil {
+		return nil, err
+	}
+	if err := s.ExpireReservations(ctx, bookID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetActiveReservation(ctx, bookID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrDuplicateReservation
+	}
+
+	// Patrons only queue for books they cannot borrow right away at any branch
+	availability, err := s.copyRepo.GetAvailability(ctx, bookID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if availability.Available > 0 {
+		return nil, ErrBookAvailable
+	}
+
+	newReservation := model.Reservation{
+		BookID:    bookID,
+		MemberID:  memberID,
+		Status:    model.ReservationStatusPending,
+		CreatedAt: time.Now().Unix(),
+	}
+	id, err := s.repo.CreateReservation(ctx, newReservation)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetReservationByID(ctx, id)
+}
+
+func (s *reservationService) CancelReservation(ctx context.Context, id int) error {
+	r, err := s.GetReservation(ctx, id)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return ErrReservationNotFound
+	}
+	if !r.IsActive() {
+		return ErrReservationNotActive
+	}
+
+	err = s.end(ctx, r, model.ReservationStatusCancelled)
+	if errors.Is(err, repository.ErrNoRowsUpdated) {
+		// Another request ended or fulfilled the reservation in the meantime
+		return ErrReservationNotActive
+	}
+	return err
+}
+
+func (s *reservationService) ReleaseCopy(ctx context.Context, bookID, copyID int) error {
+	next, err := s.repo.GetNextPendingReservation(ctx, bookID)
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		return s.copyRepo.UpdateCopyStatus(ctx, copyID, model.CopyStatusAvailable)
+	}
+
+	now := time.Now()
+	err = s.repo.MarkReservationReady(ctx, next.ID, copyID, now.Unix(), now.Add(s.pickupPeriod).Unix())
+	if err != nil {
+		return err
+	}
+	return s.copyRepo.UpdateCopyStatus(ctx, copyID, model.CopyStatusOnHold)
+}
+
+func (s *reservationService) FindHeldReservation(ctx context.Context, bookID, memberID int) (*model.Reservation, error) {
+	r, err := s.repo.GetActiveReservation(ctx, bookID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil || r.Status != model.ReservationStatusReady {
+		return nil, nil
+	}
+	return r, nil
+}
+
+func (s *reservationService) FulfillReservation(ctx context.Context, id int) error {
+	err := s.repo.UpdateReservationStatus(ctx, id, model.ReservationStatusReady, model.ReservationStatusFulfilled)
+	if errors.Is(err, repository.ErrNoRowsUpdated) {
+		// The hold expired or was cancelled in the meantime
+		return ErrReservationNotActive
+	}
+	return err
+}
+
+func (s *reservationService) HasOtherReservations(ctx context.Context, bookID, memberID int) (bool, error) {
+	count, err := s.repo.CountActiveReservations(ctx, bookID, memberID)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (s *reservationService) ExpireReservations(ctx context.Context, bookID int) error {
+	expired, err := s.repo.GetExpiredReservations(ctx, bookID, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+	for i := range expired {
+		err = s.end(ctx, &expired[i], model.ReservationStatusExpired)
+		if err != nil && !errors.Is(err, repository.ErrNoRowsUpdated) {
+			// Reservations already ended by a concurrent request are skipped
+			return err
+		}
+	}
+	return nil
+}
+
+// end moves an active reservation to a final status and releases its held copy,
+// in a single transaction. It returns repository.ErrNoRowsUpdated, releasing
+// nothing, when another request changed the reservation since r was read.
+func (s *reservationService) end(ctx context.Context, r *model.Reservation, status string) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.repo.UpdateReservationStatus(ctx, r.ID, r.Status, status)
+		if err != nil {
+			return err
+		}
+		if r.Status == model.ReservationStatusReady && r.CopyID != nil {
+			return s.ReleaseCopy(ctx, r.BookID, *r.CopyID)
+		}
+		return nil
+	})
+}
+
+func (s *reservationService) ensureBookExists(ctx context.Context, bookID int) error {
+	b, err := s.bookRepo.GetBookByID(ctx, bookID)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return ErrBookNotFound
+	}
+	return nil
+}
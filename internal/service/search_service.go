@@ -0,0 +1,65 @@
+package service
+
+import (
+	"borrow_book/internal/config"
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/repository"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Bounds of the number of hits returned by a search.
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// SearchService defines the interface for full-text search operations
+type SearchService interface {
+	// Search ranks the books and authors matching q, at most limit of them.
+	// An empty types searches both kinds of records.
+	Search(ctx context.Context, q string, types []string, limit int) ([]model.SearchHit, error)
+}
+
+// searchService is the concrete implementation of SearchService
+type searchService struct {
+	repo     repository.SearchRepository
+	language string
+}
+
+// NewSearchService creates a new instance of SearchService
+func NewSearchService(repo repository.SearchRepository, cfg *config.Config) SearchService {
+	return &searchService{repo: repo, language: cfg.Search.Language}
+}
+
+func (s *searchService) Search(ctx context.Context, q string, types []string, limit int) ([]model.SearchHit, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, ErrEmptySearchQuery
+	}
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	if len(types) == 0 {
+		types = []string{model.SearchTypeBook, model.SearchTypeAuthor}
+	}
+	seen := make(map[string]bool)
+	unique := make([]string, 0, len(types))
+	for _, t := range types {
+		switch t {
+		case model.SearchTypeBook, model.SearchTypeAuthor:
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSearchType, t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			unique = append(unique, t)
+		}
+	}
+	return s.repo.Search(ctx, s.language, q, unique, limit)
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/query"
+	"borrow_book/internal/repository"
+	"context"
+	"strings"
+)
+
+// TagService defines the interface for tag-related operations
+type TagService interface {
+	ListTags(ctx context.Context, filters, sorts []string, fields string) ([]model.Tag, error)
+	GetTag(ctx context.Context, id int) (*model.Tag, error)
+	CreateTag(ctx context.Context, name string) (*model.Tag, error)
+	UpdateTag(ctx context.Context, id int, name string) (*model.Tag, error)
+	DeleteTag(ctx context.Context, id int) error
+	// TagBook labels a book, creating the tag when no tag has that name yet.
+	TagBook(ctx context.Context, bookID int, name string) (*model.Tag, error)
+	UntagBook(ctx context.Context, bookID, tagID int) error
+}
+
+// tagService is the concrete implementation of TagService
+type tagService struct {
+	repo     repository.TagRepository
+	bookRepo repository.BookRepository
+}
+
+// NewTagService creates a new instance of TagService
+func NewTagService(repo repository.TagRepository, bookRepo repository.BookRepository) TagService {
+	return &tagService{repo: repo, bookRepo: bookRepo}
+}
+
+func (s *tagService) ListTags(ctx context.Context, filters, sorts []string, fields string) ([]model.Tag, error) {
+	f, err := query.ParseFilters(repository.TagSchema, filters)
+	if err != nil {
+		return nil, err
+	}
+	srts, err := query.ParseSorts(repository.TagSchema, sorts)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := query.ParseFields(repository.TagSchema, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := query.QueryOptions{
+		Filters: f,
+		Sorts:   srts,
+		Fields:  fs,
+	}
+	return s.repo.GetAllTags(ctx, opts)
+}
+
+func (s *tagService) GetTag(ctx context.Context, id int) (*model.Tag, error) {
+	return s.repo.GetTagByID(ctx, id)
+}
+
+func (s *tagService) CreateTag(ctx context.Context, name string) (*model.Tag, error) {
+	name, err := s.validate(ctx, 0, name)
+	if err != nil {
+		return nil, err
+	}
+
+	newTag := model.Tag{Name: name}
+	id, err := s.repo.CreateTag(ctx, newTag)
+	if err != nil {
+		return nil, err
+	}
+	newTag.ID = id
+	return &newTag, nil
+}
+
+func (s *tagService) UpdateTag(ctx context.Context, id int, name string) (*model.Tag, error) {
+	t, err := s.GetTag(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTagNotFound
+	}
+	name, err = s.validate(ctx, id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Name = name
+	err = s.repo.UpdateTag(ctx, *t)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, id int) error {
+	t, err := s.GetTag(ctx, id)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return ErrTagNotFound
+	}
+	return s.repo.DeleteTag(ctx, id)
+}
+
+func (s *tagService) TagBook(ctx context.Context, bookID int, name string) (*model.Tag, error) {
+	if err := s.ensureBookExists(ctx, bookID); err != nil {
+		return nil, err
+	}
+	t, err := s.repo.GetTagByName(ctx, normalizeTag(name))
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		t, err = s.CreateTag(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = s.repo.AddBookTag(ctx, bookID, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (s *tagService) UntagBook(ctx context.Context, bookID, tagID int) error {
+	if err := s.ensureBookExists(ctx, bookID); err != nil {
+		return err
+	}
+	err := s.repo.RemoveBookTag(ctx, bookID, tagID)
+	if err != nil && err.Error() == "no rows deleted" {
+		return ErrTagNotFound
+	}
+	return err
+}
+
+// validate normalizes a tag name and checks that no other tag has it.
+func (s *tagService) validate(ctx context.Context, id int, name string) (string, error) {
+	name = normalizeTag(name)
+	if name == "" {
+		return "", ErrInvalidTag
+	}
+	existing, err := s.repo.GetTagByName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil && existing.ID != id {
+		return "", ErrDuplicateTag
+	}
+	return name, nil
+}
+
+func (s *tagService) ensureBookExists(ctx context.Context, bookID int) error {
+	b, err := s.bookRepo.GetBookByID(ctx, bookID)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return ErrBookNotFound
+	}
+	return nil
+}
+
+// normalizeTag lower cases a tag name and collapses its spaces, so that "Sci Fi" and " sci  fi" are the same tag.
+func normalizeTag(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
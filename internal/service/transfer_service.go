@@ -0,0 +1,235 @@
+package service
+
+import (
+	"borrow_book/internal/domain/model"
+	"borrow_book/internal/infra/database/postgres"
+	"borrow_book/internal/infra/database/query"
+	"borrow_book/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TransferService defines the interface for moving copies between branches.
+// A transfer is requested, then shipped, then received at its destination,
+// and can be cancelled until it is shipped.
+type TransferService interface {
+	ListTransfers(ctx context.Context, filters, sorts []string, fields string) ([]model.Transfer, error)
+	GetTransfer(ctx context.Context, id int) (*model.Transfer, error)
+	ListTransferEvents(ctx context.Context, id int) ([]model.TransferEvent, error)
+	RequestTransfer(ctx context.Context, copyID, toBranchID int, note string) (*model.Transfer, error)
+	ShipTransfer(ctx context.Context, id int, note string) (*model.Transfer, error)
+	ReceiveTransfer(ctx context.Context, id int, note string) (*model.Transfer, error)
+	CancelTransfer(ctx context.Context, id int, note string) (*model.Transfer, error)
+}
+
+// transferService is the concrete implementation of TransferService
+type transferService struct {
+	repo           repository.TransferRepository
+	copyRepo       repository.BookCopyRepository
+	branchSvc      BranchService
+	reservationSvc ReservationService
+	txManager      postgres.TxManager
+}
+
+// NewTransferService creates a new instance of TransferService
+func NewTransferService(
+	repo repository.TransferRepository,
+	copyRepo repository.BookCopyRepository,
+	branchSvc BranchService,
+	reservationSvc ReservationService,
+	txManager postgres.TxManager,
+) TransferService {
+	return &transferService{
+		repo:           repo,
+		copyRepo:       copyRepo,
+		branchSvc:      branchSvc,
+		reservationSvc: reservationSvc,
+		txManager:      txManager,
+	}
+}
+
+func (s *transferService) ListTransfers(ctx context.Context, filters, sorts []string, fields string) ([]model.Transfer, error) {
+	f, err := query.ParseFilters(repository.TransferSchema, filters)
+	if err != nil {
+		return nil, err
+	}
+	srts, err := query.ParseSorts(repository.TransferSchema, sorts)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := query.ParseFields(repository.TransferSchema, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := query.QueryOptions{
+		Filters: f,
+		Sorts:   srts,
+		Fields:  fs,
+	}
+	return s.repo.GetAllTransfers(ctx, opts)
+}
+
+func (s *transferService) GetTransfer(ctx context.Context, id int) (*model.Transfer, error) {
+	return s.repo.GetTransferByID(ctx, id)
+}
+
+func (s *transferService) ListTransferEvents(ctx context.Context, id int) ([]model.TransferEvent, error) {
+	if _, err := s.getTransfer(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTransferEvents(ctx, id)
+}
+
+func (s *transferService) RequestTransfer(ctx context.Context, copyID, toBranchID int, note string) (*model.Transfer, error) {
+	c, err := s.copyRepo.GetCopyByID(ctx, copyID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrCopyNotFound
+	}
+	if err := s.branchSvc.EnsureBranchExists(ctx, toBranchID); err != nil {
+		return nil, err
+	}
+	if c.BranchID == toBranchID {
+		return nil, ErrTransferSameBranch
+	}
+	open, err := s.repo.GetOpenTransferByCopyID(ctx, copyID)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, fmt.Errorf("%w: transfer %d", ErrTransferExists, open.ID)
+	}
+
+	t := model.Transfer{
+		CopyID:       copyID,
+		FromBranchID: c.BranchID,
+		ToBranchID:   toBranchID,
+		Status:       model.TransferStatusRequested,
+		RequestedAt:  time.Now().Unix(),
+		Note:         note,
+	}
+	id, err := s.repo.CreateTransfer(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTransfer(ctx, id)
+}
+
+// ShipTransfer sends the copy on its way. The copy must be available at the
+// branch it is transferred from, it stays in transit until received. The copy
+// and the transfer change in a single transaction.
+func (s *transferService) ShipTransfer(ctx context.Context, id int, note string) (*model.Transfer, error) {
+	t, err := s.getTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != model.TransferStatusRequested {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTransferStep, t.Status)
+	}
+	c, err := s.copyRepo.GetCopyByID(ctx, t.CopyID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrCopyNotFound
+	}
+	if c.BranchID != t.FromBranchID {
+		// The copy was returned to another branch since the transfer was requested
+		return nil, ErrCopyBranchMismatch
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		claimed, err := s.copyRepo.TransitionCopyStatus(ctx, t.CopyID, model.CopyStatusAvailable, model.CopyStatusInTransit)
+		if err != nil {
+			return err
+		}
+		if claimed == nil {
+			return ErrCopyNotAvailable
+		}
+		return s.advance(ctx, t, model.TransferStatusInTransit, note)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTransfer(ctx, id)
+}
+
+// ReceiveTransfer records the arrival of the copy at its destination, where it
+// serves the patrons waiting for the book first. The transfer, the copy and
+// the reservations change in a single transaction.
+func (s *transferService) ReceiveTransfer(ctx context.Context, id int, note string) (*model.Transfer, error) {
+	t, err := s.getTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != model.TransferStatusInTransit {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTransferStep, t.Status)
+	}
+	c, err := s.copyRepo.GetCopyByID(ctx, t.CopyID)
+	if err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrCopyNotFound
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.advance(ctx, t, model.TransferStatusReceived, note); err != nil {
+			return err
+		}
+		if err := s.copyRepo.MoveCopy(ctx, c.ID, t.ToBranchID); err != nil {
+			return err
+		}
+		return s.reservationSvc.ReleaseCopy(ctx, c.BookID, c.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTransfer(ctx, id)
+}
+
+func (s *transferService) CancelTransfer(ctx context.Context, id int, note string) (*model.Transfer, error) {
+	t, err := s.getTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != model.TransferStatusRequested {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTransferStep, t.Status)
+	}
+	err = s.advance(ctx, t, model.TransferStatusCancelled, note)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTransfer(ctx, id)
+}
+
+// getTransfer returns the transfer, or ErrTransferNotFound when it does not exist.
+func (s *transferService) getTransfer(ctx context.Context, id int) (*model.Transfer, error) {
+	t, err := s.repo.GetTransferByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTransferNotFound
+	}
+	return t, nil
+}
+
+// advance moves the transfer to the given status and records the step.
+func (s *transferService) advance(ctx context.Context, t *model.Transfer, status, note string) error {
+	err := s.repo.AdvanceTransfer(ctx, t.ID, t.Status, model.TransferEvent{
+		Status:     status,
+		OccurredAt: time.Now().Unix(),
+		Note:       note,
+	})
+	if errors.Is(err, repository.ErrNoRowsUpdated) {
+		// Another request moved the transfer in the meantime
+		return fmt.Errorf("%w: %s", ErrInvalidTransferStep, t.Status)
+	}
+	return err
+}
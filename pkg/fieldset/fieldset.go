@@ -0,0 +1,28 @@
+// Package fieldset narrows JSON objects down to a set of their fields.
+package fieldset
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Select encodes v, which must encode to a JSON object, and keeps the given
+// fields of it. Fields missing from the object are left out.
+func Select(v interface{}, fields ...string) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("fieldset: %T is not a JSON object: %w", v, err)
+	}
+
+	selected := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if value, ok := object[f]; ok {
+			selected[f] = value
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,33 @@
+package fieldset
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	ID          int     `json:"id"`
+	Title       string  `json:"title"`
+	PublishedAt string  `json:"published_at"`
+	Publisher   *string `json:"publisher,omitempty"`
+}
+
+func TestSelect(t *testing.T) {
+	selected, err := Select(item{ID: 1, Title: "Dune", PublishedAt: "1965-08-01"}, "title", "publisher", "unknown")
+	assert.NoError(t, err)
+	data, err := json.Marshal(selected)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"title": "Dune"}`, string(data))
+
+	publisher := "Chilton"
+	selected, err = Select(&item{ID: 1, Publisher: &publisher}, "id", "publisher")
+	assert.NoError(t, err)
+	data, err = json.Marshal(selected)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id": 1, "publisher": "Chilton"}`, string(data))
+
+	_, err = Select([]int{1}, "id")
+	assert.Error(t, err)
+}
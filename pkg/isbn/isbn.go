@@ -0,0 +1,102 @@
+// Package isbn validates and normalizes International Standard Book Numbers.
+package isbn
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidLength   = errors.New("isbn must have 10 or 13 digits")
+	ErrInvalidChar     = errors.New("isbn contains an invalid character")
+	ErrInvalidChecksum = errors.New("isbn check digit does not match")
+)
+
+// Normalize validates an ISBN-10 or ISBN-13, with or without hyphens and
+// spaces, and returns it as an ISBN-13 of digits only.
+func Normalize(raw string) (string, error) {
+	s := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(raw))
+	switch len(s) {
+	case 10:
+		if err := validate10(s); err != nil {
+			return "", err
+		}
+		return to13(s), nil
+	case 13:
+		if err := validate13(s); err != nil {
+			return "", err
+		}
+		return s, nil
+	default:
+		return "", ErrInvalidLength
+	}
+}
+
+// To10 converts a normalized ISBN-13 to its ISBN-10 form. ISBN-13s outside
+// the 978 prefix have no ISBN-10 and yield an empty string.
+func To10(isbn13 string) string {
+	if len(isbn13) != 13 || !strings.HasPrefix(isbn13, "978") {
+		return ""
+	}
+	body := isbn13[3:12]
+	sum := 0
+	for i, r := range body {
+		sum += (10 - i) * int(r-'0')
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return body + "X"
+	}
+	return body + string(rune('0'+check))
+}
+
+func validate10(s string) error {
+	sum := 0
+	for i, r := range s {
+		var d int
+		switch {
+		case r >= '0' && r <= '9':
+			d = int(r - '0')
+		case r == 'X' && i == 9:
+			d = 10
+		default:
+			return ErrInvalidChar
+		}
+		sum += (10 - i) * d
+	}
+	if sum%11 != 0 {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
+
+func validate13(s string) error {
+	sum := 0
+	for i, r := range s {
+		if r < '0' || r > '9' {
+			return ErrInvalidChar
+		}
+		d := int(r - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	if sum%10 != 0 {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
+
+func to13(isbn10 string) string {
+	body := "978" + isbn10[:9]
+	sum := 0
+	for i, r := range body {
+		d := int(r - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return body + string(rune('0'+(10-sum%10)%10))
+}
@@ -0,0 +1,35 @@
+package isbn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+		err  error
+	}{
+		{raw: "978-0-306-40615-7", want: "9780306406157"},
+		{raw: "0-306-40615-2", want: "9780306406157"},
+		{raw: "0 8044 2957 x", want: "9780804429573"},
+		{raw: "979-10-90636-07-1", want: "9791090636071"},
+		{raw: "978-0-306-40615-8", err: ErrInvalidChecksum},
+		{raw: "0-306-40615-3", err: ErrInvalidChecksum},
+		{raw: "03064X6152", err: ErrInvalidChar},
+		{raw: "12345", err: ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		got, err := Normalize(tt.raw)
+		assert.Equal(t, tt.err, err, tt.raw)
+		assert.Equal(t, tt.want, got, tt.raw)
+	}
+}
+
+func TestTo10(t *testing.T) {
+	assert.Equal(t, "0306406152", To10("9780306406157"))
+	assert.Equal(t, "080442957X", To10("9780804429573"))
+	assert.Equal(t, "", To10("9791090636071"))
+}
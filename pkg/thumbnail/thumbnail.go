@@ -0,0 +1,61 @@
+// Package thumbnail scales images down to fit thumbnail sizes.
+package thumbnail
+
+import (
+	"image"
+	"image/color"
+)
+
+// Size returns the dimensions of an image of width w and height h scaled down
+// to fit in maxWidth by maxHeight, keeping its aspect ratio. Images that already
+// fit keep their size. A max of 0 leaves that dimension unbounded.
+func Size(w, h, maxWidth, maxHeight int) (int, int) {
+	if maxWidth > 0 && w > maxWidth {
+		h = max(1, h*maxWidth/w)
+		w = maxWidth
+	}
+	if maxHeight > 0 && h > maxHeight {
+		w = max(1, w*maxHeight/h)
+		h = maxHeight
+	}
+	return w, h
+}
+
+// Fit scales src down to fit in maxWidth by maxHeight, averaging the source
+// pixels covered by each thumbnail pixel. Images that already fit are returned as is.
+func Fit(src image.Image, maxWidth, maxHeight int) image.Image {
+	b := src.Bounds()
+	w, h := Size(b.Dx(), b.Dy(), maxWidth, maxHeight)
+	if w == b.Dx() && h == b.Dy() {
+		return src
+	}
+
+	dst := image.NewRGBA64(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0 := b.Min.Y + y*b.Dy()/h
+		y1 := max(y0+1, b.Min.Y+(y+1)*b.Dy()/h)
+		for x := 0; x < w; x++ {
+			x0 := b.Min.X + x*b.Dx()/w
+			x1 := max(x0+1, b.Min.X+(x+1)*b.Dx()/w)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					bl += uint64(pb)
+					a += uint64(pa)
+					n++
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(bl / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
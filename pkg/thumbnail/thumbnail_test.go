@@ -0,0 +1,43 @@
+package thumbnail
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSize(t *testing.T) {
+	tests := []struct {
+		w, h, maxWidth, maxHeight int
+		wantW, wantH              int
+	}{
+		{w: 600, h: 900, maxWidth: 300, maxHeight: 0, wantW: 300, wantH: 450},
+		{w: 600, h: 900, maxWidth: 300, maxHeight: 300, wantW: 200, wantH: 300},
+		{w: 200, h: 300, maxWidth: 300, maxHeight: 600, wantW: 200, wantH: 300},
+		{w: 1000, h: 1, maxWidth: 100, maxHeight: 0, wantW: 100, wantH: 1},
+	}
+	for _, tt := range tests {
+		w, h := Size(tt.w, tt.h, tt.maxWidth, tt.maxHeight)
+		assert.Equal(t, tt.wantW, w)
+		assert.Equal(t, tt.wantH, h)
+	}
+}
+
+func TestFit(t *testing.T) {
+	// Left half black, right half white
+	src := image.NewGray(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 2; x < 4; x++ {
+			src.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+
+	dst := Fit(src, 2, 0)
+	assert.Equal(t, image.Rect(0, 0, 2, 1), dst.Bounds())
+	assert.Equal(t, color.RGBA64{A: 0xffff}, dst.At(0, 0))
+	assert.Equal(t, color.RGBA64{R: 0xffff, G: 0xffff, B: 0xffff, A: 0xffff}, dst.At(1, 0))
+
+	assert.Same(t, src, Fit(src, 10, 10))
+}